@@ -0,0 +1,67 @@
+package chess
+
+// AttackersTo returns the pieces of the color that attack the square.
+func (pos *Position) AttackersTo(sq Square, c Color) Bitboard {
+	return Bitboard(attackersBitboard(&pos.board, sq, pos.bbOccupied) & pos.getColor(c))
+}
+
+// Attacks returns the squares attacked by the piece on the square,
+// including the squares occupied by pieces of the same color.
+//
+// Pawns attack diagonally, their pushes are not attacks.
+// Returns an empty set if the square is empty.
+func (pos *Position) Attacks(sq Square) Bitboard {
+	return Bitboard(attacksBitboard(pos.pieceAt(sq), sq, pos.bbOccupied))
+}
+
+// IsAttacked indicates whether the square is attacked by a piece of the color.
+func (pos *Position) IsAttacked(sq Square, by Color) bool {
+	return attackersBitboard(&pos.board, sq, pos.bbOccupied)&pos.getColor(by) > 0
+}
+
+// Pinned returns the pieces of the color that are absolutely pinned
+// to their king.
+func (pos *Position) Pinned(c Color) Bitboard {
+	return Bitboard(pos.getPinned(c))
+}
+
+// Checkers returns the pieces that give check to the current player.
+func (pos *Position) Checkers() Bitboard {
+	return Bitboard(pos.getCheck(pos.turn.Other()))
+}
+
+// Bitboard returns the squares occupied by the piece.
+func (pos *Position) Bitboard(p Piece) Bitboard {
+	return Bitboard(pos.getBitboard(p))
+}
+
+// Occupied returns the squares occupied by the pieces of the color.
+func (pos *Position) Occupied(c Color) Bitboard {
+	return Bitboard(pos.getColor(c))
+}
+
+// attacksBitboard returns the squares attacked by a piece on a square
+// given an occupancy.
+func attacksBitboard(p Piece, sq Square, occupied bitboard) bitboard {
+	switch p {
+	case WhitePawn:
+		return bbWhitePawnCaptures[sq]
+	case BlackPawn:
+		return bbBlackPawnCaptures[sq]
+	}
+
+	switch p.Type() {
+	case Knight:
+		return bbKnightMoves[sq]
+	case Bishop:
+		return bishopAttacksBitboard(sq, occupied)
+	case Rook:
+		return rookAttacksBitboard(sq, occupied)
+	case Queen:
+		return rookAttacksBitboard(sq, occupied) | bishopAttacksBitboard(sq, occupied)
+	case King:
+		return bbKingMoves[sq]
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,84 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_Attacks(t *testing.T) {
+	pos := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+
+	tests := []struct {
+		name string
+		args Square
+		want []Square
+	}{
+		{"empty square", E3, nil},
+		{"white pawn", A2, []Square{B3}},
+		{"black pawn", B4, []Square{A3, C3}},
+		{"knight", C3, []Square{A4, B1, B5, D1, A2, E2, D5, E4}},
+		{"bishop", D2, []Square{C1, E1, C3, E3, F4, G5, H6}},
+		{"rook", A1, []Square{A2, B1, C1, D1, E1}},
+		{"queen", F3, []Square{C3, D3, E3, G3, H3, E2, G2, F2, F4, F5, F6, G4, H5, E4}},
+		{"king", E1, []Square{D1, F1, D2, E2, F2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, pos.Attacks(tt.args).Squares())
+		})
+	}
+}
+
+func TestPosition_AttackersTo(t *testing.T) {
+	for _, tt := range perfResults {
+		pos := unsafeFEN(tt.fen)
+		t.Run(tt.name, func(t *testing.T) {
+			for sq := A1; sq <= H8; sq++ {
+				for _, c := range []Color{White, Black} {
+					var want []Square
+					pos.PieceMap(func(p Piece, s Square) {
+						if p.Color() == c && pos.Attacks(s).Has(sq) {
+							want = append(want, s)
+						}
+					})
+
+					attackers := pos.AttackersTo(sq, c)
+					assert.ElementsMatch(t, want, attackers.Squares(), "%s %s", sq, c)
+					assert.Equal(t, len(want) > 0, pos.IsAttacked(sq, c), "%s %s", sq, c)
+				}
+			}
+		})
+	}
+}
+
+func TestPosition_Pinned(t *testing.T) {
+	pos := unsafeFEN("4k3/4r3/8/b7/8/2P5/4B3/4K3 w - - 0 1")
+	assert.Equal(t, []Square{E2, C3}, pos.Pinned(White).Squares())
+	assert.Empty(t, pos.Pinned(Black).Squares())
+}
+
+func TestPosition_Checkers(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []Square
+	}{
+		{"no check", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", nil},
+		{"single check", "4k3/8/8/8/8/5n2/8/4K3 w - - 0 1", []Square{F3}},
+		{"double check", "4k3/4r3/8/8/8/5n2/8/4K3 w - - 0 1", []Square{F3, E7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unsafeFEN(tt.args).Checkers().Squares())
+		})
+	}
+}
+
+func TestPosition_Bitboard(t *testing.T) {
+	pos := StartingPosition()
+	assert.Equal(t, []Square{B1, G1}, pos.Bitboard(WhiteKnight).Squares())
+	assert.Equal(t, 16, pos.Occupied(Black).Count())
+}
@@ -0,0 +1,108 @@
+package chess
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// PositionSize is the size in bytes of a position in binary format.
+//
+//	30 bytes
+//	occupancy  8 bytes  occupied squares
+//	pieces    16 bytes  4 bits per occupied square, in square order
+//	metadata   4 bytes  packed Metadata
+//	rooks      2 bytes  3 bits per castling right, file of the castle rook
+//
+// Multi-byte fields are big-endian.
+const PositionSize = 30
+
+var errInvalidBinary = errors.New("invalid binary position")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// Returns the position in a fixed size binary format of PositionSize bytes.
+//
+// Positions with more than 32 pieces cannot be encoded.
+func (pos *Position) MarshalBinary() ([]byte, error) {
+	return pos.appendBinary(make([]byte, 0, PositionSize))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// Decodes a position encoded with MarshalBinary.
+func (pos *Position) UnmarshalBinary(data []byte) error {
+	if len(data) != PositionSize {
+		return errInvalidBinary
+	}
+
+	occupied := bitboard(binary.BigEndian.Uint64(data[0:8]))
+	if occupied.ones() > 32 {
+		return errInvalidBinary
+	}
+
+	var b board
+	for i, bb := 0, occupied; bb > 0; i, bb = i+1, bb.resetLSB() {
+		p := Piece(data[8+i/2]>>(4*(i%2))) & 15
+		if p >= NoPiece {
+			return errInvalidBinary
+		}
+
+		sq := bb.scanForward().bitboard()
+		b.xorBitboard(p.Type(), sq)
+		b.xorColor(p.Color(), sq)
+	}
+	b.bbOccupied = occupied
+	b.computeConvenienceBitboards()
+
+	meta := Metadata(binary.BigEndian.Uint32(data[24:28]))
+	if meta.enPassant() > NoSquare {
+		return errInvalidBinary
+	}
+
+	files := binary.BigEndian.Uint16(data[28:30])
+	rooks := standardCastleRooks
+	for i := range rooks {
+		rooks[i] = NewSquare(File(files>>(3*i))&7, rooks[i].Rank())
+	}
+
+	*pos = Position{
+		board:          b,
+		turn:           meta.turn(),
+		castlingRights: meta.castleRights(),
+		castleRooks:    rooks,
+		enPassant:      meta.enPassant(),
+		halfMoveClock:  meta.halfMoveClock(),
+		fullMoves:      meta.fullMoves(),
+	}
+	pos.hash = zobristHash(pos)
+	pos.pawnHash = pawnZobristHash(pos.board)
+	pos.materialHash = materialZobristHash(pos.board)
+
+	return nil
+}
+
+// appendBinary appends the position in binary format to the slice.
+func (pos *Position) appendBinary(dst []byte) ([]byte, error) {
+	if pos.bbOccupied.ones() > 32 {
+		return dst, errInvalidBinary
+	}
+
+	var pieces [16]byte
+	for i, bb := 0, pos.bbOccupied; bb > 0; i, bb = i+1, bb.resetLSB() {
+		p := pos.board.pieceAt(bb.scanForward())
+		pieces[i/2] |= byte(p) << (4 * (i % 2))
+	}
+
+	var files uint16
+	for i, sq := range pos.castleRooks {
+		files |= uint16(sq.File()) << (3 * i)
+	}
+
+	meta := newMetadata(pos.turn, pos.castlingRights,
+		pos.halfMoveClock, pos.fullMoves, pos.enPassant)
+
+	dst = binary.BigEndian.AppendUint64(dst, uint64(pos.bbOccupied))
+	dst = append(dst, pieces[:]...)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(meta))
+	dst = binary.BigEndian.AppendUint16(dst, files)
+
+	return dst, nil
+}
@@ -0,0 +1,80 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_MarshalBinary(t *testing.T) {
+	fens := []string{
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"8/8/3k4/8/8/4K3/8/R7 w - - 42 87",
+		"bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9",
+		"1r2kr2/8/8/8/8/8/8/1R2KR2 w FBfb - 0 1",
+	}
+	for _, tt := range perfResults {
+		fens = append(fens, tt.fen)
+	}
+
+	for _, fen := range fens {
+		t.Run(fen, func(t *testing.T) {
+			pos := unsafeFEN(fen)
+			data, err := pos.MarshalBinary()
+			assert.Nil(t, err)
+			assert.Len(t, data, PositionSize)
+
+			got := &Position{}
+			err = got.UnmarshalBinary(data)
+			assert.Nil(t, err)
+			assert.Equal(t, pos, got)
+			assert.Equal(t, pos.FEN(), got.FEN())
+		})
+	}
+}
+
+func TestPosition_UnmarshalBinary_Invalid(t *testing.T) {
+	valid, _ := StartingPosition().MarshalBinary()
+
+	tooManyPieces := append([]byte{}, valid...)
+	copy(tooManyPieces, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	noPiece := append([]byte{}, valid...)
+	noPiece[8] = byte(NoPiece)
+
+	enPassant := append([]byte{}, valid...)
+	enPassant[24] = byte(NoSquare + 1)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"short", valid[:PositionSize-1]},
+		{"too many pieces", tooManyPieces},
+		{"no piece", noPiece},
+		{"en passant", enPassant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Position{}).UnmarshalBinary(tt.data)
+			assert.Equal(t, errInvalidBinary, err)
+		})
+	}
+}
+
+func BenchmarkPosition_MarshalBinary(b *testing.B) {
+	pos := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	for n := 0; n < b.N; n++ {
+		_, _ = pos.MarshalBinary()
+	}
+}
+
+func BenchmarkPosition_UnmarshalBinary(b *testing.B) {
+	data, _ := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1").MarshalBinary()
+	pos := &Position{}
+	for n := 0; n < b.N; n++ {
+		_ = pos.UnmarshalBinary(data)
+	}
+}
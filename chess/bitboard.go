@@ -50,6 +50,13 @@ func (b bitboard) scanForward() Square {
 	return deBruijnMap[i]
 }
 
+// bitboard can't be 0
+//
+// returns the most significant bit
+func (b bitboard) scanReverse() Square {
+	return Square(63 - bits.LeadingZeros64(uint64(b)))
+}
+
 // resets lowest significant bit
 func (b bitboard) resetLSB() bitboard {
 	return b & (b - 1)
@@ -106,13 +113,34 @@ const (
 	bbBlackSquares = ^bbWhiteSquares
 )
 
-const (
-	bbWhiteKingCastle        = 1<<F1 | 1<<H1
-	bbWhiteQueenCastle       = 1<<A1 | 1<<D1
-	bbBlackKingCastle        = 1<<F8 | 1<<H8
-	bbBlackQueenCastle       = 1<<A8 | 1<<D8
-	bbWhiteKingCastleTravel  = 1<<E1 | 1<<F1 | 1<<G1 | 1<<H1
-	bbWhiteQueenCastleTravel = 1<<A1 | 1<<C1 | 1<<D1 | 1<<E1
-	bbBlackKingCastleTravel  = 1<<E8 | 1<<F8 | 1<<G8 | 1<<H8
-	bbBlackQueenCastleTravel = 1<<A8 | 1<<C8 | 1<<D8 | 1<<E8
-)
+// Bitboard is a set of squares encoded in an unsigned 64-bit integer. The
+// 64 board positions have A1 as the least significant bit and H8 as the most.
+type Bitboard uint64
+
+// Has indicates whether the square is in the set.
+func (b Bitboard) Has(sq Square) bool {
+	return bitboard(b).occupied(sq)
+}
+
+// Count returns the number of squares in the set.
+func (b Bitboard) Count() int {
+	return bitboard(b).ones()
+}
+
+// Squares returns the squares in the set, from A1 to H8.
+func (b Bitboard) Squares() []Square {
+	return bitboard(b).mapping()
+}
+
+// ForEach calls the function for each square in the set, from A1 to H8.
+func (b Bitboard) ForEach(cb func(sq Square)) {
+	for bb := bitboard(b); bb > 0; bb = bb.resetLSB() {
+		cb(bb.scanForward())
+	}
+}
+
+// String implements the Stringer interface.
+// Returns a 64 character string of 1s and 0s starting with the most significant bit.
+func (b Bitboard) String() string {
+	return bitboard(b).String()
+}
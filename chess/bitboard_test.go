@@ -84,3 +84,19 @@ func TestBitboard_Occupied(t *testing.T) {
 		})
 	}
 }
+
+func TestBitboard_Exported(t *testing.T) {
+	bb := Bitboard(newBitboard([]Square{A1, E4, H8}))
+
+	assert.True(t, bb.Has(E4))
+	assert.False(t, bb.Has(E5))
+	assert.Equal(t, 3, bb.Count())
+	assert.Equal(t, []Square{A1, E4, H8}, bb.Squares())
+
+	var squares []Square
+	bb.ForEach(func(sq Square) {
+		squares = append(squares, sq)
+	})
+	assert.Equal(t, []Square{A1, E4, H8}, squares)
+	assert.Equal(t, bitboard(bb).String(), bb.String())
+}
@@ -85,6 +85,17 @@ func (b board) pieceByColor(sq Square, c Color) Piece {
 }
 
 func (b *board) makeMoveBoard(m Move) {
+	b.movePieces(m)
+	b.computeConvenienceBitboards()
+}
+
+// movePieces moves the pieces of a move without updating the pins and checks.
+func (b *board) movePieces(m Move) {
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		b.makeCastleBoard(m)
+		return
+	}
+
 	p1, p2 := m.P1(), m.P2()
 	s1, s2 := m.S1(), m.S2()
 	c := p1.Color()
@@ -117,27 +128,25 @@ func (b *board) makeMoveBoard(m Move) {
 		b.bbPawn ^= bb
 		b.bbWhite ^= bb
 		b.bbOccupied ^= mbb ^ bb
-	case c == White && m.HasTag(KingSideCastle): // white king side castle
-		b.bbRook ^= bbWhiteKingCastle
-		b.bbWhite ^= bbWhiteKingCastle
-		b.bbOccupied ^= bbWhiteKingCastleTravel
-	case c == White && m.HasTag(QueenSideCastle): // white queen side castle
-		b.bbRook ^= bbWhiteQueenCastle
-		b.bbWhite ^= bbWhiteQueenCastle
-		b.bbOccupied ^= bbWhiteQueenCastleTravel
-	case c == Black && m.HasTag(KingSideCastle): // black king side castle
-		b.bbRook ^= bbBlackKingCastle
-		b.bbBlack ^= bbBlackKingCastle
-		b.bbOccupied ^= bbBlackKingCastleTravel
-	case c == Black && m.HasTag(QueenSideCastle): // black queen side castle
-		b.bbRook ^= bbBlackQueenCastle
-		b.bbBlack ^= bbBlackQueenCastle
-		b.bbOccupied ^= bbBlackQueenCastleTravel
 	default: // quiet
 		b.bbOccupied ^= mbb
 	}
+}
 
-	b.computeConvenienceBitboards()
+// makeCastleBoard moves the king and the rook of a castling move.
+//
+// The king and the rook may start or end on each other's squares in
+// Chess960, which the exclusive or handles: a square both left and
+// entered stays occupied.
+func (b *board) makeCastleBoard(m Move) {
+	king, rook := castleDestinations(m)
+	bbKing := m.S1().bitboard() ^ king.bitboard()
+	bbRook := m.S2().bitboard() ^ rook.bitboard()
+
+	b.bbKing ^= bbKing
+	b.bbRook ^= bbRook
+	b.xorColor(m.P1().Color(), bbKing^bbRook)
+	b.bbOccupied ^= bbKing ^ bbRook
 }
 
 func (b board) hasSufficientMaterial() bool {
@@ -76,7 +76,7 @@ var (
 			postFEN: "2r3k1/1q1nbppp/r3p3/3pP3/2pP4/PpQ2N2/2RN1PPP/2R4K w - - 0 24",
 		},
 		{
-			move:    newMove(WhiteKing, NoPiece, E1, G1, NoSquare, NoPiece),
+			move:    newMove(WhiteKing, WhiteRook, E1, H1, NoSquare, NoPiece),
 			moveUCI: "e1g1",
 			tags:    []MoveTag{KingSideCastle},
 			preFEN:  "r2qk2r/pp1n1ppp/2pbpn2/3p4/2PP4/1PNQPN2/P4PPP/R1B1K2R w KQkq - 1 9",
@@ -90,7 +90,7 @@ var (
 			postFEN: "rnbqkbnr/pppppppp/8/8/8/5N2/PPPPPPPP/RNBQKB1R b KQkq - 1 1",
 		},
 		{
-			move:    newMove(WhiteKing, NoPiece, E1, C1, NoSquare, NoPiece),
+			move:    newMove(WhiteKing, WhiteRook, E1, A1, NoSquare, NoPiece),
 			moveUCI: "e1c1",
 			tags:    []MoveTag{QueenSideCastle},
 			preFEN:  "r3k2r/ppqn1ppp/2pbpn2/3p4/2PP4/1PNQPN2/P2B1PPP/R3K2R w KQkq - 3 10",
@@ -0,0 +1,106 @@
+package chess
+
+// castleRooks holds the origin square of the rook of each castling right,
+// in the order of the CastlingRights bits: white king side, white queen
+// side, black king side and black queen side.
+//
+// In standard chess, the rooks start on the corners. In Chess960, they
+// may start on any file as long as the king is between them.
+type castleRooks [4]Square
+
+// standardCastleRooks are the rook squares of standard chess.
+var standardCastleRooks = castleRooks{H1, A1, H8, A8}
+
+// castleIndex returns the index of the castling right of the color and side.
+func castleIndex(c Color, s Side) int {
+	if c == White {
+		return int(s)
+	}
+	return 2 + int(s)
+}
+
+// castleRight returns the castling right of the color and side.
+func castleRight(c Color, s Side) CastlingRights {
+	return 1 << castleIndex(c, s)
+}
+
+// castleDestinations returns the squares occupied by the king and the rook
+// after a castling move, which are the same in standard chess and Chess960.
+func castleDestinations(m Move) (king, rook Square) {
+	r := m.S1().Rank()
+	if m.HasTag(KingSideCastle) {
+		return NewSquare(FileG, r), NewSquare(FileF, r)
+	}
+	return NewSquare(FileC, r), NewSquare(FileD, r)
+}
+
+// castlePath returns the squares from s1 to s2, both included.
+func castlePath(s1, s2 Square) bitboard {
+	return bbInBetween[s1][s2] | s1.bitboard() | s2.bitboard()
+}
+
+// castleRookSquare returns the square of the rook of a castling right
+// given in the FEN castling field.
+//
+// The letters K and Q designate the outermost rook on each side of the
+// king (X-FEN), file letters designate the rook on that file (Shredder-FEN).
+// If the king is not on its back rank, it is assumed to be on the e file.
+func castleRookSquare(b board, c Color, r rune) (Square, Side) {
+	rank := Rank1
+	if c == Black {
+		rank = Rank8
+	}
+
+	king := b.getKingSquare(c)
+	if b.getBitboard(King.color(c)) == 0 || king.Rank() != rank {
+		king = NewSquare(FileE, rank)
+	}
+
+	rooks := b.getBitboard(Rook.color(c)) & bbRanks[king]
+	kingSide := rooks & ^(king.bitboard()<<1 - 1)
+	queenSide := rooks & (king.bitboard() - 1)
+
+	switch r {
+	case 'K', 'k':
+		if kingSide == 0 {
+			return NewSquare(FileH, rank), KingSide
+		}
+		return kingSide.scanReverse(), KingSide
+	case 'Q', 'q':
+		if queenSide == 0 {
+			return NewSquare(FileA, rank), QueenSide
+		}
+		return queenSide.scanForward(), QueenSide
+	}
+
+	f := File(r - 'A')
+	if c == Black {
+		f = File(r - 'a')
+	}
+
+	sq := NewSquare(f, rank)
+	if f > king.File() {
+		return sq, KingSide
+	}
+	return sq, QueenSide
+}
+
+// Chess960 reports whether the castling rights of the position involve a king
+// or a rook outside of its standard chess square, which happens in Chess960.
+func (pos *Position) Chess960() bool {
+	for i, rook := range pos.castleRooks {
+		if pos.castlingRights&(1<<i) == 0 {
+			continue
+		}
+
+		c, king := White, E1
+		if i >= 2 {
+			c, king = Black, E8
+		}
+
+		if rook != standardCastleRooks[i] || pos.getKingSquare(c) != king {
+			return true
+		}
+	}
+	return false
+}
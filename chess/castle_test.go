@@ -0,0 +1,174 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCastlingMoves_Chess960(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want []string
+	}{
+		{"rook not outermost", "rk2r3/8/8/8/8/8/1P6/1R1KR2R w EBea - 0 1", []string{"d1b1", "d1e1"}},
+		{"king and rook swap", "4k3/8/8/8/8/8/8/5KR1 w K - 0 1", []string{"f1g1"}},
+		{"king on its destination", "4k3/8/8/8/8/8/8/6KR w K - 0 1", []string{"g1h1"}},
+		{"king next to its rook", "4k3/8/8/8/8/8/8/RK6 w Q - 0 1", []string{"b1a1"}},
+		{"path blocked", "4k3/8/8/8/8/8/8/RKN5 w Q - 0 1", nil},
+		{"rook path blocked", "4k3/8/8/8/8/8/8/1RNK4 w Q - 0 1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var moves []string
+			for _, m := range castlingMoves(unsafeFEN(tt.fen), allMoves) {
+				moves = append(moves, m.Chess960String())
+			}
+			assert.ElementsMatch(t, tt.want, moves)
+		})
+	}
+}
+
+func TestIsCastleLegal(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want bool
+	}{
+		{"standard", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", true},
+		{"destination attacked", "4k3/8/8/8/8/b7/8/RK6 w Q - 0 1", false},
+		{"rook shielding the destination", "4k3/8/8/8/8/8/8/rR2K3 w Q - 0 1", false},
+		{"rook attacked", "r3k3/8/8/8/8/8/8/RK6 w Q - 0 1", true},
+		{"rook leaving an attacked square", "rk2r3/8/8/8/8/8/1P6/1R1KR2R w EBea - 0 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			moves := castlingMoves(pos, allMoves)
+			if assert.NotEmpty(t, moves) {
+				assert.Equal(t, tt.want, isCastleLegal(pos, moves[0]))
+			}
+		})
+	}
+}
+
+func TestPosition_MakeMove_Chess960(t *testing.T) {
+	tests := []struct {
+		name    string
+		preFEN  string
+		move    string
+		postFEN string
+	}{
+		{
+			"king and rook swap",
+			"4k3/8/8/8/8/8/8/5KR1 w K - 0 1", "f1g1",
+			"4k3/8/8/8/8/8/8/5RK1 b - - 1 1",
+		},
+		{
+			"king on its destination",
+			"4k3/8/8/8/8/8/8/6KR w K - 0 1", "g1h1",
+			"4k3/8/8/8/8/8/8/5RK1 b - - 1 1",
+		},
+		{
+			"queen side from the b file",
+			"4k3/8/8/8/8/8/8/RK6 w Q - 0 1", "b1a1",
+			"4k3/8/8/8/8/8/8/2KR4 b - - 1 1",
+		},
+		{
+			"rook not outermost",
+			"rk5r/8/8/8/8/8/1P5P/1R1KR2R w EBha - 0 1", "d1e1",
+			"rk5r/8/8/8/8/8/1P5P/1R3RKR b kq - 1 1",
+		},
+		{
+			"rook captured",
+			"rk2r3/8/8/8/8/8/1P6/1R1KR2R b EBea - 0 1", "e8e1",
+			"rk6/8/8/8/8/8/1P6/1R1Kr2R w Qq - 0 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.preFEN)
+			m, err := MoveFromUCI(pos, tt.move)
+			assert.Nil(t, err)
+
+			meta, ok := pos.MakeMove(m)
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, tt.postFEN, pos.String())
+			assert.Equal(t, zobristHash(pos), pos.Hash())
+
+			pos.UnmakeMove(m, meta)
+			assert.Equal(t, unsafeFEN(tt.preFEN).String(), pos.String())
+			assert.Equal(t, zobristHash(pos), pos.Hash())
+		})
+	}
+}
+
+func TestMoveFromUCI_Castle(t *testing.T) {
+	tests := []struct {
+		fen  string
+		uci  string
+		want Move
+	}{
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", newMove(WhiteKing, WhiteRook, E1, H1, NoSquare, NoPiece)},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1h1", newMove(WhiteKing, WhiteRook, E1, H1, NoSquare, NoPiece)},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8c8", newMove(BlackKing, BlackRook, E8, A8, NoSquare, NoPiece)},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8a8", newMove(BlackKing, BlackRook, E8, A8, NoSquare, NoPiece)},
+		{"4k3/8/8/8/8/8/8/5K1R w K - 0 1", "f1g1", newMove(WhiteKing, NoPiece, F1, G1, NoSquare, NoPiece)},
+		{"4k3/8/8/8/8/8/8/5K1R w K - 0 1", "f1h1", newMove(WhiteKing, WhiteRook, F1, H1, NoSquare, NoPiece)},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w - - 0 1", "e1g1", newMove(WhiteKing, NoPiece, E1, G1, NoSquare, NoPiece)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen+" "+tt.uci, func(t *testing.T) {
+			m, err := MoveFromUCI(unsafeFEN(tt.fen), tt.uci)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, m)
+		})
+	}
+}
+
+func TestMove_Chess960String(t *testing.T) {
+	tests := []struct {
+		move     Move
+		uci      string
+		chess960 string
+	}{
+		{newMove(WhiteKing, WhiteRook, E1, H1, NoSquare, NoPiece), "e1g1", "e1h1"},
+		{newMove(BlackKing, BlackRook, E8, A8, NoSquare, NoPiece), "e8c8", "e8a8"},
+		{newMove(WhiteKing, WhiteRook, G1, H1, NoSquare, NoPiece), "g1g1", "g1h1"},
+		{newMove(WhitePawn, NoPiece, B7, B8, NoSquare, WhiteQueen), "b7b8q", "b7b8q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uci, func(t *testing.T) {
+			assert.Equal(t, tt.uci, tt.move.String())
+			assert.Equal(t, tt.chess960, tt.move.Chess960String())
+		})
+	}
+}
+
+func TestPosition_Chess960(t *testing.T) {
+	tests := []struct {
+		fen  string
+		want bool
+	}{
+		{startFEN, false},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1", false},
+		{"bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9", true},
+		{"bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w - - 2 9", false},
+		{"r3k2r/8/8/8/8/8/8/1R2K2R w Kkq - 0 1", false},
+		{"r3k2r/8/8/8/8/8/8/1R2K2R w KQkq - 0 1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen, func(t *testing.T) {
+			assert.Equal(t, tt.want, unsafeFEN(tt.fen).Chess960())
+		})
+	}
+}
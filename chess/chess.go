@@ -1,19 +1,169 @@
 // Package chess provides types and functions to handle chess positions.
 package chess
 
+// generation selects the moves to generate.
+type generation uint8
+
+const (
+	allMoves     generation = iota // all moves
+	captureMoves                   // captures and promotions
+	quietMoves                     // moves that neither capture nor promote
+)
+
 func pseudoMoves(pos *Position) []Move {
+	return generateMoves(pos, allMoves)
+}
+
+// generateMoves returns the pseudo moves of the generation.
+//
+// When the current player is in check, only evasions are generated.
+func generateMoves(pos *Position, gen generation) []Move {
 	switch checks := pos.getCheck(pos.turn.Other()); checks.ones() {
 	case 0: // no check
-		return append(standardMoves(pos), castlingMoves(pos)...)
+		return append(standardMoves(pos, gen), castlingMoves(pos, gen)...)
 	case 1: // single check
-		return append(checkAttackAndInterposingMoves(pos), checkFlightMoves(pos)...)
+		return append(checkAttackAndInterposingMoves(pos, gen), checkFlightMoves(pos, gen)...)
 	default: // double check and more
-		return checkFlightMoves(pos)
+		return checkFlightMoves(pos, gen)
+	}
+}
+
+// quietChecks returns the quiet pseudo moves that give check.
+func quietChecks(pos *Position) []Move {
+	moves := generateMoves(pos, quietMoves)
+	checks := moves[:0]
+	for _, m := range moves {
+		if givesCheck(pos, m) {
+			checks = append(checks, m)
+		}
+	}
+	return checks
+}
+
+// givesCheck checks whether a move gives check to the opponent,
+// directly or by discovery.
+func givesCheck(pos *Position, m Move) bool {
+	c, op := pos.turn, pos.turn.Other()
+	b := pos.board
+	b.movePieces(m)
+	own := b.getColor(c)
+	return checkBitboard(b.getKingSquare(op), op, b.bbOccupied,
+		0, own&b.bbQueen, own&b.bbRook,
+		own&b.bbBishop, own&b.bbKnight, own&b.bbPawn) > 0
+}
+
+// targetBitboard returns the destination squares of the pieces of a type
+// allowed by the generation.
+func targetBitboard(pos *Position, gen generation, pt PieceType) bitboard {
+	c := pos.turn
+	var bbPawnOnly bitboard
+	if pt == Pawn {
+		bbPawnOnly = pos.enPassant.bitboard() | bbRank1 | bbRank8
+	}
+
+	switch gen {
+	case captureMoves:
+		return pos.getColor(c.Other()) | bbPawnOnly
+	case quietMoves:
+		return ^pos.bbOccupied & ^bbPawnOnly
+	default:
+		return ^pos.getColor(c)
+	}
+}
+
+// appendMoves appends the moves of a piece from s1 to the squares of bbS2,
+// pawns reaching the last rank generate one move per promotion.
+func appendMoves(moves []Move, pos *Position, p1 Piece, s1 Square, bbS2 bitboard) []Move {
+	c := pos.turn
+	for ; bbS2 > 0; bbS2 = bbS2.resetLSB() {
+		s2 := bbS2.scanForward()
+		p2 := pos.board.pieceByColor(s2, c.Other())
+
+		if p1 == WhitePawn && s2.Rank() == Rank8 || p1 == BlackPawn && s2.Rank() == Rank1 {
+			moves = append(moves,
+				newMove(p1, p2, s1, s2, pos.enPassant, Queen.color(c)),
+				newMove(p1, p2, s1, s2, pos.enPassant, Rook.color(c)),
+				newMove(p1, p2, s1, s2, pos.enPassant, Bishop.color(c)),
+				newMove(p1, p2, s1, s2, pos.enPassant, Knight.color(c)),
+			)
+		} else {
+			moves = append(moves, newMove(p1, p2, s1, s2, pos.enPassant, NoPiece))
+		}
+	}
+	return moves
+}
+
+func legalMoves(pos *Position) []Move {
+	moves := pseudoMoves(pos)
+	legal := moves[:0]
+	for _, m := range moves {
+		if isLegal(pos, m) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// legalMoveCount counts the legal moves without collecting them.
+func legalMoveCount(pos *Position) int {
+	var n int
+	for _, m := range pseudoMoves(pos) {
+		if isLegal(pos, m) {
+			n++
+		}
+	}
+	return n
+}
+
+func hasLegalMoves(pos *Position) bool {
+	for _, m := range pseudoMoves(pos) {
+		if isLegal(pos, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLegal checks whether a pseudo move generated by pseudoMoves is legal.
+//
+// Pinned pieces, check evasions and interpositions are already handled by
+// the generator, only king moves, castles and en passant captures need
+// to be verified.
+func isLegal(pos *Position, m Move) bool {
+	c, s1, s2 := pos.turn, m.S1(), m.S2()
+
+	switch {
+	case m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle):
+		return isCastleLegal(pos, m)
+	case m.P1().Type() == King:
+		occupied := pos.bbOccupied ^ s1.bitboard()
+		return !isAttacked(pos, s2, c, occupied, 0)
+	case m.HasTag(EnPassant):
+		captured := s2 - 8
+		if c == Black {
+			captured = s2 + 8
+		}
+		occupied := pos.bbOccupied ^ s1.bitboard() ^ s2.bitboard() ^ captured.bitboard()
+		return !isAttacked(pos, pos.getKingSquare(c), c, occupied, captured.bitboard())
+	case pos.getPinned(c)&s1.bitboard() > 0:
+		return bbLines[pos.getKingSquare(c)][s1]&s2.bitboard() > 0
+	default:
+		return true
 	}
 }
 
+// isAttacked checks whether the square sq would be attacked by the opponent
+// of the color c given an occupancy. Opponent pieces in the removed bitboard
+// are not taken into account.
+func isAttacked(pos *Position, sq Square, c Color, occupied, removed bitboard) bool {
+	op := pos.getColor(c.Other()) & ^removed
+	return checkBitboard(sq, c, occupied,
+		op&pos.bbKing, op&pos.bbQueen, op&pos.bbRook,
+		op&pos.bbBishop, op&pos.bbKnight, op&pos.bbPawn) > 0
+}
+
 // assumes there is only one checking piece
-func checkAttackAndInterposingMoves(pos *Position) []Move {
+func checkAttackAndInterposingMoves(pos *Position, gen generation) []Move {
 	c := pos.turn
 	bbChecking := pos.getCheck(c.Other())
 	sqKing, sqChecking := pos.getKingSquare(c), bbChecking.scanForward()
@@ -22,9 +172,11 @@ func checkAttackAndInterposingMoves(pos *Position) []Move {
 
 	var moves []Move
 	for p1 := Pawn.color(c); p1 <= WhiteQueen; p1 += 2 {
+		bbAllowed := targetBitboard(pos, gen, p1.Type())
+
 		for bbS1 := pos.getBitboard(p1) & ^bbPinned; bbS1 > 0; bbS1 = bbS1.resetLSB() {
 			s1 := bbS1.scanForward()
-			bbS2 := moveBitboard(s1, pos, p1.Type())
+			bbS2 := moveBitboard(s1, pos, p1.Type()) & bbAllowed
 
 			bbTarget := bbChecking
 			if p1.Type() == Pawn && pos.pieceAt(sqChecking).Type() == Pawn {
@@ -32,40 +184,21 @@ func checkAttackAndInterposingMoves(pos *Position) []Move {
 			}
 
 			// attacks to the attacking piece (not pinned)
-			for bbAttack := bbS2 & bbTarget; bbAttack > 0; bbAttack = bbAttack.resetLSB() {
-				s2 := bbAttack.scanForward()
-				p2 := pos.pieceAt(s2)
-
-				if p1 == WhitePawn && s2.Rank() == Rank8 || p1 == BlackPawn && s2.Rank() == Rank1 {
-					moves = append(moves,
-						newMove(p1, p2, s1, s2, pos.enPassant, Queen.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Rook.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Bishop.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Knight.color(c)),
-					)
-				} else {
-					moves = append(moves, newMove(p1, p2, s1, s2, pos.enPassant, NoPiece))
-				}
-			}
+			moves = appendMoves(moves, pos, p1, s1, bbS2&bbTarget)
 
 			// interposing moves in case of distance sliding checks (not pinned)
-			for bbInterpose := bbS2 & bbBetween; bbInterpose > 0; bbInterpose = bbInterpose.resetLSB() {
-				s2 := bbInterpose.scanForward()
-
-				// there is always a clear path so p2 is always NoPiece
-				moves = append(moves, newMove(p1, NoPiece, s1, s2, NoSquare, NoPiece))
-			}
+			moves = appendMoves(moves, pos, p1, s1, bbS2&bbBetween)
 		}
 	}
 	return moves
 }
 
 // king moves to non attacked squares
-func checkFlightMoves(pos *Position) []Move {
+func checkFlightMoves(pos *Position, gen generation) []Move {
 	c, op := pos.turn, pos.turn.Other()
 
 	bbFlight := bbKingMoves[pos.getKingSquare(c)]
-	bbFlight &= ^pos.getColor(c)                    // possible moves
+	bbFlight &= targetBitboard(pos, gen, King)      // possible moves
 	bbFlight &= ^bbKingMoves[pos.getKingSquare(op)] // enemy king attacks
 
 	// pawn attacks
@@ -100,68 +233,50 @@ func checkFlightMoves(pos *Position) []Move {
 		bbFlight &= ^bishopAttacksBitboard(sq, bbOccupiedNoKing)
 	}
 
-	var moves []Move
-	for s1 := pos.getKingSquare(c); bbFlight > 0; bbFlight = bbFlight.resetLSB() {
-		s2 := bbFlight.scanForward()
-		p1, p2 := King.color(c), pos.pieceAt(s2)
-		moves = append(moves, newMove(p1, p2, s1, s2, NoSquare, NoPiece))
-	}
-	return moves
+	return appendMoves(nil, pos, King.color(c), pos.getKingSquare(c), bbFlight)
 }
 
-const (
-	bbKingCastleTravel  bitboard = 1<<F1 | 1<<G1
-	bbQueenCastleTravel bitboard = 1<<B1 | 1<<C1 | 1<<D1
-)
+// castlingMoves returns the castling moves whose path is free, without
+// checking whether the king would pass through an attacked square.
+//
+// Castling moves are encoded as the king capturing its own rook.
+func castlingMoves(pos *Position, gen generation) []Move {
+	if gen == captureMoves {
+		return nil
+	}
 
-func castlingMoves(pos *Position) []Move {
 	var moves []Move
 
 	c := pos.turn
-	bbKingTravel, bbQueenTravel := bbKingCastleTravel, bbQueenCastleTravel
-	sqKing, sqKingSide, sqQueenSide := E1, G1, C1
-	if c == Black {
-		bbKingTravel <<= 56
-		bbQueenTravel <<= 56
-		sqKing += 56
-		sqKingSide += 56
-		sqQueenSide += 56
-	}
+	king := pos.getKingSquare(c)
+	bbRooks := pos.getBitboard(Rook.color(c))
 
-	if pos.castlingRights.CanCastle(c, KingSide) &&
-		pos.bbOccupied&bbKingTravel == 0 {
-		moves = append(moves, newMove(
-			King.color(c),
-			NoPiece,
-			sqKing,
-			sqKingSide,
-			NoSquare,
-			NoPiece,
-		))
-	}
+	for _, side := range [2]Side{KingSide, QueenSide} {
+		rook := pos.castleRooks[castleIndex(c, side)]
+		if !pos.castlingRights.CanCastle(c, side) || bbRooks&rook.bitboard() == 0 {
+			continue
+		}
 
-	if pos.castlingRights.CanCastle(c, QueenSide) &&
-		pos.bbOccupied&bbQueenTravel == 0 {
-		moves = append(moves, newMove(
-			King.color(c),
-			NoPiece,
-			sqKing,
-			sqQueenSide,
-			NoSquare,
-			NoPiece,
-		))
+		m := newMove(King.color(c), Rook.color(c), king, rook, NoSquare, NoPiece)
+		kingTo, rookTo := castleDestinations(m)
+		bbPath := castlePath(king, kingTo) | castlePath(rook, rookTo)
+		bbOthers := pos.bbOccupied & ^(king.bitboard() | rook.bitboard())
+		if bbPath&bbOthers == 0 {
+			moves = append(moves, m)
+		}
 	}
 
 	return moves
 }
 
-func standardMoves(pos *Position) []Move {
+func standardMoves(pos *Position, gen generation) []Move {
 	c := pos.turn
-	bbAllowed := ^pos.getColor(c)
 	bbPinned := pos.getPinned(c)
 
 	var moves []Move
 	for p1 := Pawn.color(c); p1 <= WhiteKing; p1 += 2 {
+		bbAllowed := targetBitboard(pos, gen, p1.Type())
+
 		for bbS1 := pos.board.getBitboard(p1); bbS1 > 0; bbS1 = bbS1.resetLSB() {
 			s1 := bbS1.scanForward()
 
@@ -172,58 +287,27 @@ func standardMoves(pos *Position) []Move {
 				bbS2 = moveBitboard(s1, pos, p1.Type()) & bbAllowed
 			}
 
-			for ; bbS2 > 0; bbS2 = bbS2.resetLSB() {
-				s2 := bbS2.scanForward()
-
-				p2 := pos.board.pieceByColor(s2, c.Other())
-				if p1 == WhitePawn && s2.Rank() == Rank8 || p1 == BlackPawn && s2.Rank() == Rank1 {
-					moves = append(moves,
-						newMove(p1, p2, s1, s2, pos.enPassant, Queen.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Rook.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Bishop.color(c)),
-						newMove(p1, p2, s1, s2, pos.enPassant, Knight.color(c)),
-					)
-				} else {
-					moves = append(moves, newMove(p1, p2, s1, s2, pos.enPassant, NoPiece))
-				}
-			}
+			moves = appendMoves(moves, pos, p1, s1, bbS2)
 		}
 	}
 	return moves
 }
 
+// isCastleLegal checks that none of the squares from the king's origin to
+// its destination are attacked. The king and the castling rook are removed
+// from the occupancy, as in Chess960 the rook may shield a square it leaves.
 func isCastleLegal(pos *Position, m Move) bool {
-	var index int
-	if m.HasTag(QueenSideCastle) {
-		index |= 1
-	}
-	if pos.turn == Black {
-		index |= 2
-	}
-
-	c := pos.turn.Other()
-	check := castleChecks[index]
-
-	if check.bbPawn&pos.getBitboard(Pawn.color(c)) > 0 ||
-		check.bbKnight&pos.getBitboard(Knight.color(c)) > 0 ||
-		check.bbKing&pos.getBitboard(King.color(c)) > 0 {
-		return false
-	}
-
-	var bbBishop, bbRook bitboard
-	for _, sq := range check.squares {
-		bbBishop |= bishopAttacksBitboard(sq, pos.bbOccupied)
-	}
-
-	if bb := pos.getBitboard(Bishop.color(c)) | pos.getBitboard(Queen.color(c)); bbBishop&bb > 0 {
-		return false
-	}
+	c := pos.turn
+	king, _ := castleDestinations(m)
+	occupied := pos.bbOccupied ^ m.S1().bitboard() ^ m.S2().bitboard()
 
-	for _, sq := range check.squares {
-		bbRook |= rookAttacksBitboard(sq, pos.bbOccupied)
+	for bb := castlePath(m.S1(), king); bb > 0; bb = bb.resetLSB() {
+		if isAttacked(pos, bb.scanForward(), c, occupied, 0) {
+			return false
+		}
 	}
 
-	return bbRook&(pos.getBitboard(Rook.color(c))|pos.getBitboard(Queen.color(c))) == 0
+	return true
 }
 
 func movePinnedBitboard(sq Square, pos *Position, pt PieceType) bitboard {
@@ -238,12 +322,8 @@ func movePinnedBitboard(sq Square, pos *Position, pt PieceType) bitboard {
 	case Knight:
 		return 0 // knights are always absolutely pinned
 	case Pawn:
-		pinner := pos.getPinner(pos.turn.Other())
-		bb := pinner & pawnCapturesBitboard(sq, pos)
-		if bbFiles[sq]&pinner > 0 {
-			bb |= pawnPushesBitboard(sq, pos)
-		}
-		return bb
+		// pinned pawns can only push or capture along the pin line
+		return bbLines[king][sq] & (pawnPushesBitboard(sq, pos) | pawnCapturesBitboard(sq, pos))
 	default:
 		return 0
 	}
@@ -294,12 +374,8 @@ func pawnCapturesBitboard(sq Square, pos *Position) bitboard {
 }
 
 func checkBitboard(sq Square, c Color, occupied, k, q, r, b, n, p bitboard) bitboard {
-	bbRank := (q | r) & linearBitboard(sq, occupied, bbRanks[sq])
-	bbFile := (q | r) & linearBitboard(sq, occupied, bbFiles[sq])
-	bbDiag := (q | b) & linearBitboard(sq, occupied, bbDiagonals[sq])
-	bbAnti := (q | b) & linearBitboard(sq, occupied, bbAntiDiagonals[sq])
-
-	bbCheck := bbRank | bbFile | bbDiag | bbAnti
+	bbCheck := (q | r) & rookAttacksBitboard(sq, occupied)
+	bbCheck |= (q | b) & bishopAttacksBitboard(sq, occupied)
 	bbCheck |= k & bbKingMoves[sq]
 	bbCheck |= n & bbKnightMoves[sq]
 
@@ -313,18 +389,8 @@ func checkBitboard(sq Square, c Color, occupied, k, q, r, b, n, p bitboard) bitb
 func pinnedBitboard(sq Square, occupied, blockers, queen, rook, bishop bitboard) (bitboard, bitboard) {
 	var pinned, pinner bitboard
 
-	if bbRanks[sq]&(queen|rook) > 0 {
-		rPinner := xrayRankAttacksBitboard(sq, occupied, blockers) & (queen | rook)
-		pinner |= rPinner
-
-		for ; rPinner > 0; rPinner = rPinner.resetLSB() {
-			s := rPinner.scanForward()
-			pinned |= bbInBetween[sq][s] & blockers
-		}
-	}
-
-	if bbFiles[sq]&(queen|rook) > 0 {
-		rPinner := xrayFileAttacksBitboard(sq, occupied, blockers) & (queen | rook)
+	if (bbRanks[sq]|bbFiles[sq])&(queen|rook) > 0 {
+		rPinner := xrayRookAttacksBitboard(sq, occupied, blockers) & (queen | rook)
 		pinner |= rPinner
 
 		for ; rPinner > 0; rPinner = rPinner.resetLSB() {
@@ -333,18 +399,8 @@ func pinnedBitboard(sq Square, occupied, blockers, queen, rook, bishop bitboard)
 		}
 	}
 
-	if bbDiagonals[sq]&(queen|bishop) > 0 {
-		bPinner := xrayDiagonalAttacksBitboard(sq, occupied, blockers) & (queen | bishop)
-		pinner |= bPinner
-
-		for ; bPinner > 0; bPinner = bPinner.resetLSB() {
-			s := bPinner.scanForward()
-			pinned |= bbInBetween[sq][s] & blockers
-		}
-	}
-
-	if bbAntiDiagonals[sq]&(queen|bishop) > 0 {
-		bPinner := xrayAntiDiagonalAttacksBitboard(sq, occupied, blockers) & (queen | bishop)
+	if (bbDiagonals[sq]|bbAntiDiagonals[sq])&(queen|bishop) > 0 {
+		bPinner := xrayBishopAttacksBitboard(sq, occupied, blockers) & (queen | bishop)
 		pinner |= bPinner
 
 		for ; bPinner > 0; bPinner = bPinner.resetLSB() {
@@ -356,65 +412,22 @@ func pinnedBitboard(sq Square, occupied, blockers, queen, rook, bishop bitboard)
 	return pinned, pinner
 }
 
-func xrayRankAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
-	attacks := linearBitboard(sq, occupied, bbRanks[sq])
+func xrayRookAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
+	attacks := rookAttacksBitboard(sq, occupied)
 	blockers &= attacks
-	return attacks ^ linearBitboard(sq, occupied^blockers, bbRanks[sq])
+	return attacks ^ rookAttacksBitboard(sq, occupied^blockers)
 }
 
-func xrayFileAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
-	attacks := linearBitboard(sq, occupied, bbFiles[sq])
+func xrayBishopAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
+	attacks := bishopAttacksBitboard(sq, occupied)
 	blockers &= attacks
-	return attacks ^ linearBitboard(sq, occupied^blockers, bbFiles[sq])
-}
-
-func xrayDiagonalAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
-	attacks := linearBitboard(sq, occupied, bbDiagonals[sq])
-	blockers &= attacks
-	return attacks ^ linearBitboard(sq, occupied^blockers, bbDiagonals[sq])
-}
-
-func xrayAntiDiagonalAttacksBitboard(sq Square, occupied, blockers bitboard) bitboard {
-	attacks := linearBitboard(sq, occupied, bbAntiDiagonals[sq])
-	blockers &= attacks
-	return attacks ^ linearBitboard(sq, occupied^blockers, bbAntiDiagonals[sq])
+	return attacks ^ bishopAttacksBitboard(sq, occupied^blockers)
 }
 
 func pinnedBishopAttacksBitboard(sq, king Square, occupied bitboard) bitboard {
-	if bb := bbDiagonals[sq]; bb&king.bitboard() > 0 {
-		return linearBitboard(sq, occupied, bb)
-	}
-
-	if bb := bbAntiDiagonals[sq]; bb&king.bitboard() > 0 {
-		return linearBitboard(sq, occupied, bb)
-	}
-
-	return 0
+	return bishopAttacksBitboard(sq, occupied) & bbLines[king][sq]
 }
 
 func pinnedRookAttacksBitboard(sq, king Square, occupied bitboard) bitboard {
-	if bb := bbRanks[sq]; bb&king.bitboard() > 0 {
-		return linearBitboard(sq, occupied, bb)
-	}
-
-	if bb := bbFiles[sq]; bb&king.bitboard() > 0 {
-		return linearBitboard(sq, occupied, bb)
-	}
-
-	return 0
-}
-
-func bishopAttacksBitboard(sq Square, occupied bitboard) bitboard {
-	return linearBitboard(sq, occupied, bbDiagonals[sq]) |
-		linearBitboard(sq, occupied, bbAntiDiagonals[sq])
-}
-
-func rookAttacksBitboard(sq Square, occupied bitboard) bitboard {
-	return linearBitboard(sq, occupied, bbRanks[sq]) |
-		linearBitboard(sq, occupied, bbFiles[sq])
-}
-
-func linearBitboard(sq Square, occupied, mask bitboard) bitboard {
-	inMask := occupied & mask
-	return ((inMask - bbDoubleSquares[sq]) ^ (inMask.reverse() - bbReverseDoubleSquares[sq]).reverse()) & mask
+	return rookAttacksBitboard(sq, occupied) & bbLines[king][sq]
 }
@@ -1,107 +1,209 @@
 package chess
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
-type perfTest struct {
-	fen   string
-	nodes []int
+// makeMoveLegalMoves is the reference implementation of legalMoves that
+// relies on MakeMove to filter out the illegal moves.
+func makeMoveLegalMoves(pos *Position) []Move {
+	var moves []Move
+	for _, m := range pseudoMoves(pos) {
+		if meta, ok := pos.MakeMove(m); ok {
+			moves = append(moves, m)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+	return moves
+}
+
+func TestLegalMoves(t *testing.T) {
+	var walk func(t *testing.T, pos *Position, depth int)
+	walk = func(t *testing.T, pos *Position, depth int) {
+		want := makeMoveLegalMoves(pos)
+		if !assert.ElementsMatch(t, want, legalMoves(pos), pos.String()) {
+			return
+		}
+		assert.Equal(t, len(want) > 0, hasLegalMoves(pos), pos.String())
+		assert.Equal(t, len(want), legalMoveCount(pos), pos.String())
+
+		if depth == 0 {
+			return
+		}
+
+		for _, m := range want {
+			meta, _ := pos.MakeMove(m)
+			walk(t, pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+
+	for _, tt := range perfResults {
+		t.Run(tt.fen, func(t *testing.T) {
+			walk(t, unsafeFEN(tt.fen), 2)
+		})
+	}
 }
 
-var perfResults = []perfTest{
-	{
-		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
-		[]int{
-			20, 400, 8902, 197281, 4865609,
-			// 119060324, 3195901860, 84998978956, 2439530234167, 69352859712417
+func TestGenerateMoves(t *testing.T) {
+	var walk func(t *testing.T, pos *Position, depth int)
+	walk = func(t *testing.T, pos *Position, depth int) {
+		captures, quiets := generateMoves(pos, captureMoves), generateMoves(pos, quietMoves)
+		if !assert.ElementsMatch(t, pseudoMoves(pos), append(captures, quiets...), pos.String()) {
+			return
+		}
+
+		for _, m := range captures {
+			assert.True(t, m.HasTag(Capture) || m.HasTag(Promotion), "%s %s", pos, m)
+		}
+
+		for _, m := range quiets {
+			assert.False(t, m.HasTag(Capture) || m.HasTag(Promotion), "%s %s", pos, m)
+		}
+
+		checks := quietChecks(pos)
+		for _, m := range quiets {
+			meta, ok := pos.MakeMove(m)
+			if !ok {
+				continue
+			}
+			assert.Equal(t, pos.InCheck(), containsMove(checks, m), "%s %s", pos, m)
+			pos.UnmakeMove(m, meta)
+		}
+
+		if depth == 0 {
+			return
+		}
+
+		for _, m := range legalMoves(pos) {
+			meta, _ := pos.MakeMove(m)
+			walk(t, pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+
+	for _, tt := range perfResults {
+		t.Run(tt.fen, func(t *testing.T) {
+			walk(t, unsafeFEN(tt.fen), 2)
+		})
+	}
+}
+
+func TestQuietChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{
+			"direct checks",
+			"4k3/8/8/7N/8/8/8/R3K3 w Q - 0 1",
+			[]string{"a1a8", "h5f6", "h5g7"},
 		},
-	},
-	{
-		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
-		[]int{
-			48, 2039, 97862, 4085603,
-			//  193690690
+		{
+			"discovered checks",
+			"4k3/8/8/8/4N3/8/8/4R1K1 w - - 0 1",
+			[]string{"e4c3", "e4c5", "e4d2", "e4d6", "e4f2", "e4f6", "e4g3", "e4g5"},
 		},
-	},
-	{
-		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
-		[]int{
-			14, 191, 2812, 43238, 674624, 11030083,
-			//  178633661
+		{
+			"castling check",
+			"5k2/8/8/8/8/8/8/4K2R w K - 0 1",
+			[]string{"e1g1", "h1f1", "h1h8"},
 		},
-	},
-	{
-		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
-		[]int{
-			6, 264, 9467, 422333, 15833292,
-			// 706045033
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var moves []string
+			for _, m := range quietChecks(unsafeFEN(tt.args)) {
+				moves = append(moves, m.String())
+			}
+			assert.ElementsMatch(t, tt.want, moves)
+		})
+	}
+}
+
+func containsMove(moves []Move, m Move) bool {
+	for _, move := range moves {
+		if move == m {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLegalMoves_EdgeCases(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{
+			"en passant discovers check on rank",
+			"8/8/8/K2pP2r/8/8/8/7k w - d6 0 1",
+			[]string{"a5a4", "a5a6", "a5b4", "a5b5", "a5b6", "e5e6"},
 		},
-	},
-	{
-		"r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1",
-		[]int{
-			6, 264, 9467, 422333, 15833292,
-			// 706045033
+		{
+			"pinned pawn captures en passant along the pin",
+			"1b6/8/8/3pP3/8/6K1/8/7k w - d6 0 1",
+			[]string{"e5d6", "g3f2", "g3f3", "g3f4", "g3g4", "g3h3", "g3h4"},
 		},
-	},
-	{
-		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
-		[]int{
-			44, 1486, 62379, 2103487, 89941194,
+		{
+			"castling through an attacked square",
+			"k4r2/8/8/8/8/8/8/4K2R w K - 0 1",
+			[]string{
+				"e1d1", "e1d2", "e1e2", "h1f1", "h1g1", "h1h2", "h1h3",
+				"h1h4", "h1h5", "h1h6", "h1h7", "h1h8",
+			},
 		},
-	},
-	{
-		"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
-		[]int{
-			46, 2079, 89890, 3894594,
-			//  164075551, 6923051137, 287188994746, 11923589843526, 490154852788714
+		{
+			"king cannot retreat along the checking ray",
+			"8/8/8/8/8/8/1k6/r3K3 w - - 0 1",
+			[]string{"e1d2", "e1e2", "e1f2"},
 		},
-	},
-}
+	}
 
-func TestPerfResults(t *testing.T) {
-	for _, tt := range perfResults {
-		for depth := 0; depth < len(tt.nodes); depth++ {
-			want := tt.nodes[depth]
-
-			if !testing.Short() || want < 2<<22 {
-				t.Run(fmt.Sprintf("%s depth %d", tt.fen, depth), func(t *testing.T) {
-					got := perft(unsafeFEN(tt.fen), depth)
-					assert.Equal(t, want, got)
-				})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var moves []string
+			for _, m := range unsafeFEN(tt.args).LegalMoves() {
+				moves = append(moves, m.String())
 			}
-		}
+			assert.ElementsMatch(t, tt.want, moves)
+		})
 	}
 }
 
-func perft(pos *Position, depth int) int {
-	if depth == 0 {
-		return len(legalMoves(pos))
-	}
-
-	var count int
-	for _, m := range pseudoMoves(pos) {
-		if meta, ok := pos.MakeMove(m); ok {
-			count += perft(pos, depth-1)
-			pos.UnmakeMove(m, meta)
-		}
+func BenchmarkLegalMoves(b *testing.B) {
+	for _, bb := range testPositions {
+		pos := unsafeFEN(bb.preFEN)
+		b.Run(bb.preFEN, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				legalMoves(pos)
+			}
+		})
 	}
-	return count
 }
 
-func legalMoves(pos *Position) []Move {
-	var moves []Move
-	for _, m := range pseudoMoves(pos) {
-		if meta, ok := pos.MakeMove(m); ok {
-			moves = append(moves, m)
-			pos.UnmakeMove(m, meta)
-		}
+func BenchmarkGenerateMoves(b *testing.B) {
+	pos := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	for _, bb := range []struct {
+		name string
+		fn   func(*Position) []Move
+	}{
+		{"all", pseudoMoves},
+		{"captures", func(pos *Position) []Move { return generateMoves(pos, captureMoves) }},
+		{"quiets", func(pos *Position) []Move { return generateMoves(pos, quietMoves) }},
+		{"quiet checks", quietChecks},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bb.fn(pos)
+			}
+		})
 	}
-	return moves
 }
 
 func BenchmarkPseudoMoves(b *testing.B) {
@@ -154,12 +256,21 @@ func TestCheckAttackAndInterposingMoves(t *testing.T) {
 				newMove(BlackPawn, WhiteRook, G2, H1, NoSquare, BlackKnight),
 			},
 		},
+		{ // interposing promotion
+			"K6r/4P3/8/8/8/8/8/7k w - - 0 1",
+			[]Move{
+				newMove(WhitePawn, NoPiece, E7, E8, NoSquare, WhiteQueen),
+				newMove(WhitePawn, NoPiece, E7, E8, NoSquare, WhiteRook),
+				newMove(WhitePawn, NoPiece, E7, E8, NoSquare, WhiteBishop),
+				newMove(WhitePawn, NoPiece, E7, E8, NoSquare, WhiteKnight),
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.args, func(t *testing.T) {
 			pos := unsafeFEN(tt.args)
-			moves := checkAttackAndInterposingMoves(pos)
+			moves := checkAttackAndInterposingMoves(pos, allMoves)
 			assert.ElementsMatch(t, tt.want, moves)
 		})
 	}
@@ -177,7 +288,7 @@ func BenchmarkCheckAttackAndInterposingMoves(b *testing.B) {
 		pos := unsafeFEN(fen)
 		b.Run(fen, func(b *testing.B) {
 			for n := 0; n < b.N; n++ {
-				checkAttackAndInterposingMoves(pos)
+				checkAttackAndInterposingMoves(pos, allMoves)
 			}
 		})
 	}
@@ -227,7 +338,7 @@ func TestCheckFlightMoves(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.args, func(t *testing.T) {
 			pos := unsafeFEN(tt.args)
-			assert.ElementsMatch(t, tt.want, checkFlightMoves(pos))
+			assert.ElementsMatch(t, tt.want, checkFlightMoves(pos, allMoves))
 		})
 	}
 }
@@ -244,7 +355,7 @@ func BenchmarkCheckFlightMoves(b *testing.B) {
 		pos := unsafeFEN(fen)
 		b.Run(fen, func(b *testing.B) {
 			for n := 0; n < b.N; n++ {
-				checkFlightMoves(pos)
+				checkFlightMoves(pos, allMoves)
 			}
 		})
 	}
@@ -263,7 +374,7 @@ func TestCastlingMoves(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.args, func(t *testing.T) {
 			var moves []string
-			for _, m := range castlingMoves(unsafeFEN(tt.args)) {
+			for _, m := range castlingMoves(unsafeFEN(tt.args), allMoves) {
 				moves = append(moves, m.String())
 			}
 			assert.ElementsMatch(t, tt.want, moves)
@@ -276,7 +387,7 @@ func BenchmarkCastlingMoves(b *testing.B) {
 		pos := unsafeFEN(bb.preFEN)
 		b.Run(bb.preFEN, func(b *testing.B) {
 			for n := 0; n < b.N; n++ {
-				castlingMoves(pos)
+				castlingMoves(pos, allMoves)
 			}
 		})
 	}
@@ -299,7 +410,7 @@ func TestStandardMoves(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.args, func(t *testing.T) {
 			var moves []string
-			for _, m := range standardMoves(unsafeFEN(tt.args)) {
+			for _, m := range standardMoves(unsafeFEN(tt.args), allMoves) {
 				moves = append(moves, m.String())
 			}
 			assert.ElementsMatch(t, tt.want, moves)
@@ -312,7 +423,7 @@ func BenchmarkStandardMoves(b *testing.B) {
 		pos := unsafeFEN(bb.preFEN)
 		b.Run(bb.preFEN, func(b *testing.B) {
 			for n := 0; n < b.N; n++ {
-				standardMoves(pos)
+				standardMoves(pos, allMoves)
 			}
 		})
 	}
@@ -0,0 +1,26 @@
+//go:build chessdebug
+
+package chess
+
+import "fmt"
+
+// debug enables the internal consistency checks of the package.
+//
+// It is set by building with the chessdebug tag:
+//
+//	go test -tags chessdebug ./chess
+const debug = true
+
+// checkHash panics if the incremental hashes differ from the hashes
+// computed from scratch.
+func (pos *Position) checkHash() {
+	if expected := zobristHash(pos); pos.hash != expected {
+		panic(fmt.Sprintf("incremental hash %#x differs from %#x (%s)", pos.hash, expected, pos))
+	}
+	if expected := pawnZobristHash(pos.board); pos.pawnHash != expected {
+		panic(fmt.Sprintf("incremental pawn hash %#x differs from %#x (%s)", pos.pawnHash, expected, pos))
+	}
+	if expected := materialZobristHash(pos.board); pos.materialHash != expected {
+		panic(fmt.Sprintf("incremental material hash %#x differs from %#x (%s)", pos.materialHash, expected, pos))
+	}
+}
@@ -0,0 +1,235 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EPD represents a record in Extended Position Description format:
+// a position followed by a list of operations.
+type EPD struct {
+	Position   *Position
+	Operations []Operation
+}
+
+// Operation represents an EPD operation, an opcode and its operands.
+type Operation struct {
+	Opcode   string
+	Operands []string
+}
+
+// FromEPD parses an EPD record.
+//
+// The half move clock and full moves of the position are read from the
+// hmvc and fmvn operations when present, and default to 0 and 1.
+func FromEPD(epd string) (*EPD, error) {
+	fields := strings.Fields(epd)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid epd (%s), must have at least 4 fields", epd)
+	}
+
+	// the operations start after the fourth field
+	rest := strings.TrimSpace(epd)
+	for _, field := range fields[:4] {
+		rest = strings.TrimSpace(rest[len(field):])
+	}
+
+	operations, err := epdOperations(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &EPD{Operations: operations}
+
+	halfMoveClock, fullMoves := "0", "1"
+	if operands, ok := e.Operation("hmvc"); ok && len(operands) == 1 {
+		halfMoveClock = operands[0]
+	}
+	if operands, ok := e.Operation("fmvn"); ok && len(operands) == 1 {
+		fullMoves = operands[0]
+	}
+
+	fen := strings.Join(append(fields[:4:4], halfMoveClock, fullMoves), " ")
+	e.Position, err = FromFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// epdOperations parses the operations of an EPD record. Operations are
+// terminated by a semicolon, operands are separated by spaces and may be
+// enclosed in double quotes.
+func epdOperations(s string) ([]Operation, error) {
+	var operations []Operation
+	var tokens []string
+	var token strings.Builder
+	var quoted, inToken bool
+
+	flushToken := func() {
+		if inToken {
+			tokens = append(tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case quoted && r == '"':
+			quoted = false
+			flushToken()
+		case quoted:
+			token.WriteRune(r)
+		case r == '"':
+			flushToken()
+			quoted, inToken = true, true
+		case r == ';':
+			flushToken()
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("invalid epd operations (%s), empty operation", s)
+			}
+			operations = append(operations, Operation{Opcode: tokens[0], Operands: tokens[1:]})
+			tokens = nil
+		case r == ' ' || r == '\t':
+			flushToken()
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	flushToken()
+	if quoted || len(tokens) > 0 {
+		return nil, fmt.Errorf("invalid epd operations (%s), unterminated operation", s)
+	}
+
+	return operations, nil
+}
+
+// Operation returns the operands of the first operation with the opcode
+// and whether it was found.
+func (e *EPD) Operation(opcode string) ([]string, bool) {
+	for _, op := range e.Operations {
+		if op.Opcode == opcode {
+			return op.Operands, true
+		}
+	}
+	return nil, false
+}
+
+// ID returns the operand of the id operation, or an empty string.
+func (e *EPD) ID() string {
+	if operands, ok := e.Operation("id"); ok && len(operands) > 0 {
+		return operands[0]
+	}
+	return ""
+}
+
+// Comment returns the operand of the comment operation cN, N being from 0 to 9,
+// or an empty string.
+func (e *EPD) Comment(n int) string {
+	if operands, ok := e.Operation("c" + strconv.Itoa(n)); ok && len(operands) > 0 {
+		return operands[0]
+	}
+	return ""
+}
+
+// BestMoves returns the moves of the bm operation.
+func (e *EPD) BestMoves() ([]Move, error) {
+	return e.moves("bm")
+}
+
+// AvoidMoves returns the moves of the am operation.
+func (e *EPD) AvoidMoves() ([]Move, error) {
+	return e.moves("am")
+}
+
+// CentipawnEvaluation returns the evaluation of the ce operation
+// and whether it was found and valid.
+func (e *EPD) CentipawnEvaluation() (int, bool) {
+	operands, ok := e.Operation("ce")
+	if !ok || len(operands) == 0 {
+		return 0, false
+	}
+
+	ce, err := strconv.Atoi(operands[0])
+	if err != nil {
+		return 0, false
+	}
+
+	return ce, true
+}
+
+// PV returns the moves of the pv operation, played successively
+// from the position.
+func (e *EPD) PV() ([]Move, error) {
+	operands, _ := e.Operation("pv")
+	pos := e.Position.Copy()
+
+	var moves []Move
+	for _, operand := range operands {
+		m, err := MoveFromSAN(pos, operand)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := pos.MakeMove(m); !ok {
+			return nil, fmt.Errorf("illegal epd pv move (%s)", operand)
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
+
+// moves returns the moves of an operation whose operands
+// are moves in Standard Algebraic Notation.
+func (e *EPD) moves(opcode string) ([]Move, error) {
+	operands, _ := e.Operation(opcode)
+
+	var moves []Move
+	for _, operand := range operands {
+		m, err := MoveFromSAN(e.Position, operand)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
+
+// String implements the Stringer interface.
+// Returns the record in Extended Position Description format.
+func (e *EPD) String() string {
+	fields := strings.Fields(e.Position.FEN())[:4]
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(fields, " "))
+	for _, op := range e.Operations {
+		sb.WriteByte(' ')
+		sb.WriteString(op.Opcode)
+		for _, operand := range op.Operands {
+			sb.WriteByte(' ')
+			if epdQuoted(op.Opcode, operand) {
+				operand = `"` + operand + `"`
+			}
+			sb.WriteString(operand)
+		}
+		sb.WriteByte(';')
+	}
+
+	return sb.String()
+}
+
+// epdQuoted indicates whether the operand should be enclosed in double quotes.
+// Identifiers and comments are always quoted.
+func epdQuoted(opcode, operand string) bool {
+	if opcode == "id" || len(opcode) == 2 && opcode[0] == 'c' && '0' <= opcode[1] && opcode[1] <= '9' {
+		return true
+	}
+	return operand == "" || strings.ContainsAny(operand, " \t;")
+}
@@ -0,0 +1,136 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromEPD(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want *EPD
+		fen  string
+		err  string
+	}{
+		{
+			name: "best move",
+			args: `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001";`,
+			want: &EPD{Operations: []Operation{
+				{Opcode: "bm", Operands: []string{"Qg6"}},
+				{Opcode: "id", Operands: []string{"WAC.001"}},
+			}},
+			fen: "2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - 0 1",
+		},
+		{
+			name: "quoted operands and clocks",
+			args: `r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - am Nxe5; c0 "quoted; with spaces"; ce -35; pv Bb5 a6; hmvc 2; fmvn 3;`,
+			want: &EPD{Operations: []Operation{
+				{Opcode: "am", Operands: []string{"Nxe5"}},
+				{Opcode: "c0", Operands: []string{"quoted; with spaces"}},
+				{Opcode: "ce", Operands: []string{"-35"}},
+				{Opcode: "pv", Operands: []string{"Bb5", "a6"}},
+				{Opcode: "hmvc", Operands: []string{"2"}},
+				{Opcode: "fmvn", Operands: []string{"3"}},
+			}},
+			fen: "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3",
+		},
+		{
+			name: "no operations",
+			args: "4k3/8/8/8/8/8/8/4K3 w - -",
+			want: &EPD{},
+			fen:  "4k3/8/8/8/8/8/8/4K3 w - - 0 1",
+		},
+		{
+			name: "missing fields",
+			args: "4k3/8/8/8/8/8/8/4K3 w -",
+			err:  "invalid epd (4k3/8/8/8/8/8/8/4K3 w -), must have at least 4 fields",
+		},
+		{
+			name: "unterminated operation",
+			args: "4k3/8/8/8/8/8/8/4K3 w - - bm Kd1",
+			err:  "invalid epd operations (bm Kd1), unterminated operation",
+		},
+		{
+			name: "unterminated string",
+			args: `4k3/8/8/8/8/8/8/4K3 w - - id "foo;`,
+			err:  `invalid epd operations (id "foo;), unterminated operation`,
+		},
+		{
+			name: "empty operation",
+			args: "4k3/8/8/8/8/8/8/4K3 w - - ;",
+			err:  "invalid epd operations (;), empty operation",
+		},
+		{
+			name: "invalid position",
+			args: "4k3/8/8/8/8/8/8/4K3 x - -",
+			err:  "invalid fen turn (x)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epd, err := FromEPD(tt.args)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want.Operations, epd.Operations)
+				assert.Equal(t, tt.fen, epd.Position.String())
+			}
+		})
+	}
+}
+
+func TestEPD_Operations(t *testing.T) {
+	epd, err := FromEPD(`r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - bm Bb5 Bc4; am Nxe5; id "test"; c0 "comment"; ce -35; pv Bb5 a6;`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "test", epd.ID())
+	assert.Equal(t, "comment", epd.Comment(0))
+	assert.Equal(t, "", epd.Comment(1))
+
+	ce, ok := epd.CentipawnEvaluation()
+	assert.True(t, ok)
+	assert.Equal(t, -35, ce)
+
+	bm, err := epd.BestMoves()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f1b5", "f1c4"}, movesToUCI(bm))
+
+	am, err := epd.AvoidMoves()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f3e5"}, movesToUCI(am))
+
+	pv, err := epd.PV()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f1b5", "a7a6"}, movesToUCI(pv))
+}
+
+func TestEPD_String(t *testing.T) {
+	for _, tt := range []string{
+		`2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001";`,
+		`r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - am Nxe5; c0 "quoted; with spaces"; pv Bb5 a6;`,
+		`4k3/8/8/8/8/8/8/4K3 w - -`,
+	} {
+		t.Run(tt, func(t *testing.T) {
+			epd, err := FromEPD(tt)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt, epd.String())
+			}
+		})
+	}
+}
+
+func movesToUCI(moves []Move) []string {
+	var s []string
+	for _, m := range moves {
+		s = append(s, m.String())
+	}
+	return s
+}
@@ -31,7 +31,7 @@ func fenFileMap(rankField string) (map[File]Piece, error) {
 	m := map[File]Piece{}
 	file := FileA
 	for _, r := range rankField {
-		if 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' {
+		if ('A' <= r && r <= 'Z' || 'a' <= r && r <= 'z') && pieceMap[r-'A'] != NoPiece {
 			m[file] = pieceMap[r-'A']
 			file++
 		} else if '1' <= r && r <= '8' {
@@ -58,29 +58,68 @@ func fenTurn(field string) (Color, error) {
 	}
 }
 
-func fenCastlingRights(field string) (CastlingRights, error) {
-	for _, s := range []string{"K", "Q", "k", "q", "-"} {
-		if strings.Count(field, s) > 1 {
-			return 0, fmt.Errorf("invalid fen castling rights (%s)", field)
-		}
+func fenCastlingRights(field string, b board) (CastlingRights, castleRooks, error) {
+	rooks := standardCastleRooks
+	if field == "-" {
+		return 0, rooks, nil
 	}
+
 	var castlingRights CastlingRights
 	for _, r := range field {
-		switch r {
-		case 'K':
-			castlingRights |= CastleWhiteKing
-		case 'Q':
-			castlingRights |= CastleWhiteQueen
-		case 'k':
-			castlingRights |= CastleBlackKing
-		case 'q':
-			castlingRights |= CastleBlackQueen
-		case '-':
+		var c Color
+		switch {
+		case r == 'K' || r == 'Q' || 'A' <= r && r <= 'H':
+			c = White
+		case r == 'k' || r == 'q' || 'a' <= r && r <= 'h':
+			c = Black
 		default:
-			return 0, fmt.Errorf("invalid fen castling rights (%s)", field)
+			return 0, standardCastleRooks, fmt.Errorf("invalid fen castling rights (%s)", field)
+		}
+
+		sq, side := castleRookSquare(b, c, r)
+		right := castleRight(c, side)
+		if castlingRights&right > 0 {
+			return 0, standardCastleRooks, fmt.Errorf("invalid fen castling rights (%s)", field)
+		}
+
+		castlingRights |= right
+		rooks[castleIndex(c, side)] = sq
+	}
+
+	return castlingRights, rooks, nil
+}
+
+// fenCastlingField returns the castling field of the position.
+//
+// Rights are written with the letters KQkq when the rook is the outermost
+// one on its side of the king (X-FEN), which covers standard chess, and
+// with the file of the rook otherwise.
+func fenCastlingField(pos Position) string {
+	if pos.castlingRights == 0 {
+		return "-"
+	}
+
+	var field []byte
+	for _, c := range [2]Color{White, Black} {
+		for _, side := range [2]Side{KingSide, QueenSide} {
+			if !pos.castlingRights.CanCastle(c, side) {
+				continue
+			}
+
+			sq := pos.castleRooks[castleIndex(c, side)]
+			letter := "KQ"[side]
+			if outermost, _ := castleRookSquare(pos.board, c, rune(letter)); outermost != sq {
+				letter = 'A' + byte(sq.File())
+			}
+			if c == Black {
+				letter += 'a' - 'A'
+			}
+
+			field = append(field, letter)
 		}
 	}
-	return castlingRights, nil
+
+	return string(field)
 }
 
 func fenEnPassantSquare(field string) (Square, error) {
@@ -65,6 +65,7 @@ func TestFENFileMap(t *testing.T) {
 		{"2p5", want{map[File]Piece{FileC: BlackPawn}, nil}},
 		{"4P3", want{map[File]Piece{FileE: WhitePawn}, nil}},
 		{"8", want{map[File]Piece{}, nil}},
+		{"7X", want{nil, errors.New("invalid fen rank field (7X)")}},
 	}
 
 	for _, tt := range tests {
@@ -102,29 +103,55 @@ func TestFENTurn(t *testing.T) {
 
 func TestFENCastlingRights(t *testing.T) {
 	type want struct {
-		cr  CastlingRights
-		err error
+		cr    CastlingRights
+		rooks castleRooks
+		err   error
 	}
 
 	tests := []struct {
 		args string
+		fen  string
 		want
 	}{
-		{"-", want{0, nil}},
-		{"KQkq", want{15, nil}},
-		{"KQ", want{3, nil}},
-		{"KKQ", want{0, errors.New("invalid fen castling rights (KKQ)")}},
+		{"-", startFEN, want{0, standardCastleRooks, nil}},
+		{"KQkq", startFEN, want{15, standardCastleRooks, nil}},
+		{"KQ", startFEN, want{3, standardCastleRooks, nil}},
+		{"KKQ", startFEN, want{0, standardCastleRooks, errors.New("invalid fen castling rights (KKQ)")}},
+		{"KHQ", startFEN, want{0, standardCastleRooks, errors.New("invalid fen castling rights (KHQ)")}},
+		{"HAha", startFEN, want{15, standardCastleRooks, nil}},
+		{"KQkq", "rk2r3/8/8/8/8/8/8/1R1KR2R w KQkq - 0 1", want{15, castleRooks{H1, B1, E8, A8}, nil}},
+		{"EBea", "rk2r3/8/8/8/8/8/8/1R1KR2R w KQkq - 0 1", want{15, castleRooks{E1, B1, E8, A8}, nil}},
+		{"X", startFEN, want{0, standardCastleRooks, errors.New("invalid fen castling rights (X)")}},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.args, func(t *testing.T) {
-			cr, err := fenCastlingRights(tt.args)
+			cr, rooks, err := fenCastlingRights(tt.args, unsafeFEN(tt.fen).board)
 			assert.Equal(t, tt.want.cr, cr)
+			assert.Equal(t, tt.want.rooks, rooks)
 			assert.Equal(t, tt.want.err, err)
 		})
 	}
 }
 
+func TestFENCastlingField(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{startFEN, "KQkq"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1", "KQkq"},
+		{"rk2r3/8/8/8/8/8/8/1R1KR2R w EBea - 0 1", "EQkq"},
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			assert.Equal(t, tt.want, fenCastlingField(*unsafeFEN(tt.args)))
+		})
+	}
+}
+
 func TestFENEnPassantSquare(t *testing.T) {
 	type want struct {
 		sq  Square
@@ -0,0 +1,175 @@
+package chess
+
+// Status represents the status of a game.
+type Status uint8
+
+const (
+	// Ongoing represents a game that is not over.
+	Ongoing Status = iota
+	// Checkmate represents a game won by checkmate.
+	Checkmate
+	// Stalemate represents a game drawn by stalemate.
+	Stalemate
+	// InsufficientMaterial represents a game drawn because neither player
+	// can checkmate.
+	InsufficientMaterial
+	// FivefoldRepetition represents a game drawn because the same position
+	// occurred five times.
+	FivefoldRepetition
+	// SeventyFiveMoveRule represents a game drawn because no capture or pawn
+	// move has been made in the last 75 moves of each player.
+	SeventyFiveMoveRule
+	// ThreefoldRepetition represents a game that can be claimed a draw
+	// because the same position occurred three times.
+	ThreefoldRepetition
+	// FiftyMoveRule represents a game that can be claimed a draw because no
+	// capture or pawn move has been made in the last 50 moves of each player.
+	FiftyMoveRule
+)
+
+var statusNames = [...]string{
+	"ongoing",
+	"checkmate",
+	"stalemate",
+	"insufficient material",
+	"fivefold repetition",
+	"seventy-five move rule",
+	"threefold repetition",
+	"fifty move rule",
+}
+
+// String implements the Stringer interface.
+func (s Status) String() string {
+	if int(s) < len(statusNames) {
+		return statusNames[s]
+	}
+	return "unknown"
+}
+
+// IsDraw indicates whether the status is a draw, either automatic or claimable.
+func (s Status) IsDraw() bool {
+	return s != Ongoing && s != Checkmate
+}
+
+// Game represents a game, that is a position and the history of the moves
+// that led to it.
+//
+// The history holds the hash of each position, which makes repetitions
+// cheap to detect. Moves are made and unmade in place, so a Game can be
+// used to track a game as well as inside a search.
+type Game struct {
+	pos      *Position
+	moves    []Move
+	metadata []Metadata
+	hashes   []uint64 // hashes of the positions, including the current one
+}
+
+// NewGame returns a new game starting from a copy of the position.
+//
+// Positions that occurred before the starting position are unknown and
+// are not taken into account to detect repetitions.
+func NewGame(pos *Position) *Game {
+	return &Game{
+		pos:    pos.Copy(),
+		hashes: []uint64{pos.Hash()},
+	}
+}
+
+// Position returns the current position.
+//
+// The position belongs to the game and should not be modified directly.
+func (g *Game) Position() *Position {
+	return g.pos
+}
+
+// Copy returns a copy of the game and its history.
+func (g *Game) Copy() *Game {
+	return &Game{
+		pos:      g.pos.Copy(),
+		moves:    append([]Move(nil), g.moves...),
+		metadata: append([]Metadata(nil), g.metadata...),
+		hashes:   append([]uint64(nil), g.hashes...),
+	}
+}
+
+// Moves returns the moves played since the starting position.
+func (g *Game) Moves() []Move {
+	return g.moves
+}
+
+// MakeMove makes a move and adds it to the history.
+// Returns false and leaves the game unchanged if the move is not valid.
+func (g *Game) MakeMove(m Move) bool {
+	meta, ok := g.pos.MakeMove(m)
+	if !ok {
+		return false
+	}
+
+	g.moves = append(g.moves, m)
+	g.metadata = append(g.metadata, meta)
+	g.hashes = append(g.hashes, g.pos.Hash())
+	return true
+}
+
+// UnmakeMove unmakes the last move and removes it from the history.
+// Does nothing if no moves have been played.
+func (g *Game) UnmakeMove() {
+	n := len(g.moves)
+	if n == 0 {
+		return
+	}
+
+	g.pos.UnmakeMove(g.moves[n-1], g.metadata[n-1])
+	g.moves = g.moves[:n-1]
+	g.metadata = g.metadata[:n-1]
+	g.hashes = g.hashes[:n]
+}
+
+// Repetitions returns the number of times the current position occurred
+// before in the game.
+//
+// Only the positions since the last capture or pawn move are compared,
+// as earlier positions cannot be repeated.
+func (g *Game) Repetitions() int {
+	var count int
+	last := len(g.hashes) - 1
+	hash := g.hashes[last]
+	limit := int(g.pos.halfMoveClock)
+	// a position cannot repeat less than 4 plies later
+	for i := 4; i <= limit && i <= last; i += 2 {
+		if g.hashes[last-i] == hash {
+			count++
+		}
+	}
+	return count
+}
+
+// Status returns the status of the game.
+//
+// Automatic endings take precedence over claimable draws: checkmate,
+// stalemate, insufficient material, fivefold repetition and the 75-move
+// rule are reported before threefold repetition and the 50-move rule.
+func (g *Game) Status() Status {
+	switch hasMoves := hasLegalMoves(g.pos); {
+	case !hasMoves && g.pos.InCheck():
+		return Checkmate
+	case !hasMoves:
+		return Stalemate
+	case !g.pos.hasSufficientMaterial():
+		return InsufficientMaterial
+	}
+
+	repetitions := g.Repetitions()
+	switch {
+	case repetitions >= 4:
+		return FivefoldRepetition
+	case g.pos.halfMoveClock >= 150:
+		return SeventyFiveMoveRule
+	case repetitions >= 2:
+		return ThreefoldRepetition
+	case g.pos.halfMoveClock >= 100:
+		return FiftyMoveRule
+	default:
+		return Ongoing
+	}
+}
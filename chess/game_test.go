@@ -0,0 +1,124 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Status(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want Status
+	}{
+		{"ongoing", startFEN, Ongoing},
+		{"checkmate", "rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3", Checkmate},
+		{"stalemate", "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", Stalemate},
+		{"king versus king", "8/8/8/4k3/8/8/8/4K3 w - - 0 1", InsufficientMaterial},
+		{"king and knight versus king", "8/8/8/4k3/8/8/8/4KN2 w - - 0 1", InsufficientMaterial},
+		{"fifty move rule", "7k/8/8/8/8/8/R7/K7 w - - 100 80", FiftyMoveRule},
+		{"seventy-five move rule", "7k/8/8/8/8/8/R7/K7 w - - 150 105", SeventyFiveMoveRule},
+		{"checkmate takes precedence over the seventy-five move rule", "R6k/8/6K1/8/8/8/8/8 b - - 150 105", Checkmate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(unsafeFEN(tt.fen))
+			assert.Equal(t, tt.want, g.Status())
+		})
+	}
+}
+
+func TestGame_Status_Repetition(t *testing.T) {
+	shuffle := []string{"g1f3", "g8f6", "f3g1", "f6g8"}
+	tests := []struct {
+		name   string
+		cycles int
+		want   Status
+	}{
+		{"twofold repetition", 1, Ongoing},
+		{"threefold repetition", 2, ThreefoldRepetition},
+		{"fourfold repetition", 3, ThreefoldRepetition},
+		{"fivefold repetition", 4, FivefoldRepetition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(StartingPosition())
+			for i := 0; i < tt.cycles; i++ {
+				for _, uci := range shuffle {
+					m, err := MoveFromUCI(g.Position(), uci)
+					assert.Nil(t, err)
+					assert.True(t, g.MakeMove(m))
+				}
+			}
+
+			assert.Equal(t, tt.cycles, g.Repetitions())
+			assert.Equal(t, tt.want, g.Status())
+		})
+	}
+}
+
+func TestGame_UnmakeMove(t *testing.T) {
+	g := NewGame(StartingPosition())
+	for _, uci := range []string{"e2e4", "e7e5", "g1f3"} {
+		m, err := MoveFromUCI(g.Position(), uci)
+		assert.Nil(t, err)
+		assert.True(t, g.MakeMove(m))
+	}
+	assert.Len(t, g.Moves(), 3)
+
+	for range g.Moves() {
+		g.UnmakeMove()
+	}
+	g.UnmakeMove()
+
+	assert.Empty(t, g.Moves())
+	assert.Equal(t, startFEN, g.Position().String())
+	assert.Equal(t, StartingPosition().Hash(), g.Position().Hash())
+}
+
+func TestGame_Copy(t *testing.T) {
+	g := NewGame(StartingPosition())
+	m, err := MoveFromUCI(g.Position(), "e2e4")
+	assert.Nil(t, err)
+	assert.True(t, g.MakeMove(m))
+
+	c := g.Copy()
+	c.UnmakeMove()
+
+	assert.Len(t, g.Moves(), 1)
+	assert.Equal(t, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", g.Position().String())
+	assert.Empty(t, c.Moves())
+	assert.Equal(t, startFEN, c.Position().String())
+}
+
+func TestGame_MakeMove_Illegal(t *testing.T) {
+	g := NewGame(unsafeFEN("4k3/8/8/8/8/8/4r3/4K3 w - - 0 1"))
+	m, err := MoveFromUCI(g.Position(), "e1d2")
+	assert.Nil(t, err)
+
+	assert.False(t, g.MakeMove(m))
+	assert.Empty(t, g.Moves())
+	assert.Equal(t, "4k3/8/8/8/8/8/4r3/4K3 w - - 0 1", g.Position().String())
+}
+
+func TestStatus_IsDraw(t *testing.T) {
+	assert.False(t, Ongoing.IsDraw())
+	assert.False(t, Checkmate.IsDraw())
+	for s := Stalemate; s <= FiftyMoveRule; s++ {
+		assert.True(t, s.IsDraw(), s.String())
+	}
+}
+
+func BenchmarkGame_Status(b *testing.B) {
+	for _, bb := range perfResults {
+		g := NewGame(unsafeFEN(bb.fen))
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				g.Status()
+			}
+		})
+	}
+}
@@ -2,13 +2,6 @@ package chess
 
 import "math"
 
-type castleCheck struct {
-	bbPawn   bitboard
-	bbKnight bitboard
-	bbKing   bitboard
-	squares  [3]Square
-}
-
 var (
 	promoPieceTypeMap = [58]PieceType{} // 'A'
 	pieceMap          = [58]Piece{}     // 'A'
@@ -21,8 +14,6 @@ var (
 		Rank5, Rank6, Rank7, Rank8,
 	} // '1'
 
-	castleChecks = [4]castleCheck{}
-
 	bbRanks                = [64]bitboard{}
 	bbFiles                = [64]bitboard{}
 	bbDiagonals            = [64]bitboard{}
@@ -36,6 +27,7 @@ var (
 	bbDoubleSquares        = [64]bitboard{}
 	bbReverseDoubleSquares = [64]bitboard{}
 	bbInBetween            = [64][64]bitboard{}
+	bbLines                = [64][64]bitboard{}
 )
 
 func init() {
@@ -63,7 +55,16 @@ func init() {
 		}
 	}
 
-	initCastleChecks()
+	for s1 := A1; s1 <= H8; s1++ {
+		for s2 := A1; s2 <= H8; s2++ {
+			bbLines[s1][s2] = initLineBitboard(s1, s2)
+		}
+	}
+
+	for sq := A1; sq <= H8; sq++ {
+		rookMagics[sq] = initMagic(sq, rookMagicNumbers[sq], slowRookAttacksBitboard)
+		bishopMagics[sq] = initMagic(sq, bishopMagicNumbers[sq], slowBishopAttacksBitboard)
+	}
 }
 
 func initPromoPieceTypeMap(r rune) PieceType {
@@ -98,33 +99,6 @@ func initPieceMap(r rune) Piece {
 	return p
 }
 
-func initCastleChecks() {
-	castles := [4]struct {
-		color   Color
-		squares [3]Square
-	}{
-		{White, [3]Square{E1, F1, G1}},
-		{White, [3]Square{C1, D1, E1}},
-		{Black, [3]Square{E8, F8, G8}},
-		{Black, [3]Square{C8, D8, E8}},
-	}
-
-	for i, castle := range castles {
-		var bbPawn, bbKnight, bbKing bitboard
-		for _, sq := range castle.squares {
-			if castle.color == White {
-				bbPawn |= bbWhitePawnCaptures[sq]
-			} else {
-				bbPawn |= bbBlackPawnCaptures[sq]
-			}
-			bbKnight |= bbKnightMoves[sq]
-			bbKing |= bbKingMoves[sq]
-		}
-
-		castleChecks[i] = castleCheck{bbPawn, bbKnight, bbKing, castle.squares}
-	}
-}
-
 func initRankBitboard(sq Square) bitboard {
 	bbRanks := [8]bitboard{bbRank1, bbRank2, bbRank3, bbRank4, bbRank5, bbRank6, bbRank7, bbRank8}
 	return bbRanks[sq.Rank()/8]
@@ -254,6 +228,22 @@ func initInBetweenBitboard(s1, s2 Square) bitboard {
 	return line & between
 }
 
+// initLineBitboard returns the full rank, file or diagonal going through
+// both squares, or an empty bitboard if they are not aligned.
+func initLineBitboard(s1, s2 Square) bitboard {
+	if s1 == s2 {
+		return 0
+	}
+
+	for _, bb := range []bitboard{bbRanks[s1], bbFiles[s1], bbDiagonals[s1], bbAntiDiagonals[s1]} {
+		if bb&s2.bitboard() > 0 {
+			return bb
+		}
+	}
+
+	return 0
+}
+
 func squareSetToSlice(set map[Square]struct{}) []Square {
 	s := []Square{}
 	for sq := range set {
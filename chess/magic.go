@@ -0,0 +1,125 @@
+package chess
+
+// magic holds the data needed to look up the attacks of a slider
+// on a square with magic bitboards.
+//
+// The occupancy of the relevant squares is multiplied by the magic number,
+// the high bits of the product index a table holding the attacks for
+// every possible occupancy.
+type magic struct {
+	mask    bitboard   // relevant occupancy, excluding the edges
+	magic   bitboard   // magic number
+	shift   uint8      // 64 minus the number of relevant squares
+	attacks []bitboard // attacks indexed by the magic product
+}
+
+var (
+	rookMagics   = [64]magic{}
+	bishopMagics = [64]magic{}
+)
+
+// rookMagicNumbers are the magic numbers of the rook attacks,
+// found with findMagic.
+var rookMagicNumbers = [64]bitboard{
+	0x0a80004000801220, 0x8040004010002008, 0x2080200010008008, 0x1100100008210004,
+	0xc200209084020008, 0x2100010004000208, 0x0400081000822421, 0x0200010422048844,
+	0x0800800080400024, 0x0001402000401000, 0x3000801000802001, 0x4400800800100083,
+	0x0904802402480080, 0x4040800400020080, 0x0018808042000100, 0x4040800080004100,
+	0x0040048001458024, 0x00a0004000205000, 0x3100808010002000, 0x4825010010000820,
+	0x5004808008000401, 0x2024818004000a00, 0x0005808002000100, 0x2100060004806104,
+	0x0080400880008421, 0x4062220600410280, 0x010a004a00108022, 0x0000100080080080,
+	0x0021000500080010, 0x0044000202001008, 0x0000100400080102, 0xc020128200040545,
+	0x0080002000400040, 0x0000804000802004, 0x0000120022004080, 0x010a386103001001,
+	0x9010080080800400, 0x8440020080800400, 0x0004228824001001, 0x000000490a000084,
+	0x0080002000504000, 0x200020005000c000, 0x0012088020420010, 0x0010010080080800,
+	0x0085001008010004, 0x0002000204008080, 0x0040413002040008, 0x0000304081020004,
+	0x0080204000800080, 0x3008804000290100, 0x1010100080200080, 0x2008100208028080,
+	0x5000850800910100, 0x8402019004680200, 0x0120911028020400, 0x0000008044010200,
+	0x0020850200244012, 0x0020850200244012, 0x0000102001040841, 0x140900040a100021,
+	0x000200282410a102, 0x000200282410a102, 0x000200282410a102, 0x4048240043802106,
+}
+
+// bishopMagicNumbers are the magic numbers of the bishop attacks,
+// found with findMagic.
+var bishopMagicNumbers = [64]bitboard{
+	0x40106000a1160020, 0x0020010250810120, 0x2010010220280081, 0x002806004050c040,
+	0x0002021018000000, 0x2001112010000400, 0x0881010120218080, 0x1030820110010500,
+	0x0000120222042400, 0x2000020404040044, 0x8000480094208000, 0x0003422a02000001,
+	0x000a220210100040, 0x8004820202226000, 0x0018234854100800, 0x0100004042101040,
+	0x0004001004082820, 0x0010000810010048, 0x1014004208081300, 0x2080818802044202,
+	0x0040880c00a00100, 0x0080400200522010, 0x0001000188180b04, 0x0080249202020204,
+	0x1004400004100410, 0x00013100a0022206, 0x2148500001040080, 0x4241080011004300,
+	0x4020848004002000, 0x10101380d1004100, 0x0008004422020284, 0x01010a1041008080,
+	0x0808080400082121, 0x0808080400082121, 0x0091128200100c00, 0x0202200802010104,
+	0x8c0a020200440085, 0x01a0008080b10040, 0x0889520080122800, 0x100902022202010a,
+	0x04081a0816002000, 0x0000681208005000, 0x8170840041008802, 0x0a00004200810805,
+	0x0830404408210100, 0x2602208106006102, 0x1048300680802628, 0x2602208106006102,
+	0x0602010120110040, 0x0941010801043000, 0x000040440a210428, 0x0008240020880021,
+	0x0400002012048200, 0x00ac102001210220, 0x0220021002009900, 0x84440c080a013080,
+	0x0001008044200440, 0x0004c04410841000, 0x2000500104011130, 0x1a0c010011c20229,
+	0x0044800112202200, 0x0434804908100424, 0x0300404822c08200, 0x48081010008a2a80,
+}
+
+// index returns the index of the attacks for the occupancy.
+func (m *magic) index(occupied bitboard) uint {
+	return uint(((occupied & m.mask) * m.magic) >> m.shift)
+}
+
+func bishopAttacksBitboard(sq Square, occupied bitboard) bitboard {
+	m := &bishopMagics[sq]
+	return m.attacks[m.index(occupied)]
+}
+
+func rookAttacksBitboard(sq Square, occupied bitboard) bitboard {
+	m := &rookMagics[sq]
+	return m.attacks[m.index(occupied)]
+}
+
+// initMagic returns the magic of a slider on a square with its attack table
+// filled using the slow attack generation.
+func initMagic(sq Square, number bitboard, slowAttacks func(Square, bitboard) bitboard) magic {
+	m := magic{mask: magicMask(sq, slowAttacks), magic: number}
+	m.shift = uint8(64 - m.mask.ones())
+	m.attacks = make([]bitboard, 1<<(64-m.shift))
+
+	for _, occupied := range magicOccupancies(m.mask) {
+		m.attacks[m.index(occupied)] = slowAttacks(sq, occupied)
+	}
+
+	return m
+}
+
+// magicMask returns the squares whose occupancy matters to the attacks
+// of a slider. Edges do not matter as there is no square behind them.
+func magicMask(sq Square, slowAttacks func(Square, bitboard) bitboard) bitboard {
+	edges := ((bbRank1 | bbRank8) & ^bbRanks[sq]) | ((bbFileA | bbFileH) & ^bbFiles[sq])
+	return slowAttacks(sq, 0) & ^edges
+}
+
+// magicOccupancies returns all the subsets of the mask (Carry-Rippler).
+func magicOccupancies(mask bitboard) []bitboard {
+	occupancies := make([]bitboard, 0, 1<<mask.ones())
+	for b := bitboard(0); ; {
+		occupancies = append(occupancies, b)
+		if b = (b - mask) & mask; b == 0 {
+			return occupancies
+		}
+	}
+}
+
+func slowBishopAttacksBitboard(sq Square, occupied bitboard) bitboard {
+	return linearBitboard(sq, occupied, bbDiagonals[sq]) |
+		linearBitboard(sq, occupied, bbAntiDiagonals[sq])
+}
+
+func slowRookAttacksBitboard(sq Square, occupied bitboard) bitboard {
+	return linearBitboard(sq, occupied, bbRanks[sq]) |
+		linearBitboard(sq, occupied, bbFiles[sq])
+}
+
+// linearBitboard returns the attacks along a line using the hyperbola
+// quintessence, used to fill the magic attack tables.
+func linearBitboard(sq Square, occupied, mask bitboard) bitboard {
+	inMask := occupied & mask
+	return ((inMask - bbDoubleSquares[sq]) ^ (inMask.reverse() - bbReverseDoubleSquares[sq]).reverse()) & mask
+}
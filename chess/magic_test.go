@@ -0,0 +1,132 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMagicNumbers(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		numbers     [64]bitboard
+		slowAttacks func(Square, bitboard) bitboard
+	}{
+		{"rook", rookMagicNumbers, slowRookAttacksBitboard},
+		{"bishop", bishopMagicNumbers, slowBishopAttacksBitboard},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for sq := A1; sq <= H8; sq++ {
+				assert.True(t, isMagic(sq, tt.numbers[sq], tt.slowAttacks), sq.String())
+			}
+		})
+	}
+}
+
+func TestFindMagic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping magic number generation in short mode")
+	}
+
+	for sq := A1; sq <= H8; sq++ {
+		assert.Equal(t, rookMagicNumbers[sq], findMagic(sq, slowRookAttacksBitboard), sq.String())
+		assert.Equal(t, bishopMagicNumbers[sq], findMagic(sq, slowBishopAttacksBitboard), sq.String())
+	}
+}
+
+func TestMagicAttacks(t *testing.T) {
+	for _, tt := range perfResults {
+		pos := unsafeFEN(tt.fen)
+		t.Run(tt.name, func(t *testing.T) {
+			for sq := A1; sq <= H8; sq++ {
+				assert.Equal(t, slowRookAttacksBitboard(sq, pos.bbOccupied), rookAttacksBitboard(sq, pos.bbOccupied))
+				assert.Equal(t, slowBishopAttacksBitboard(sq, pos.bbOccupied), bishopAttacksBitboard(sq, pos.bbOccupied))
+			}
+		})
+	}
+}
+
+func BenchmarkRookAttacks(b *testing.B) {
+	pos := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	for _, bb := range []struct {
+		name string
+		fn   func(Square, bitboard) bitboard
+	}{
+		{"magic", rookAttacksBitboard},
+		{"linear", slowRookAttacksBitboard},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bb.fn(Square(n&63), pos.bbOccupied)
+			}
+		})
+	}
+}
+
+func BenchmarkBishopAttacks(b *testing.B) {
+	pos := unsafeFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	for _, bb := range []struct {
+		name string
+		fn   func(Square, bitboard) bitboard
+	}{
+		{"magic", bishopAttacksBitboard},
+		{"linear", slowBishopAttacksBitboard},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bb.fn(Square(n&63), pos.bbOccupied)
+			}
+		})
+	}
+}
+
+// findMagic finds the magic number of a slider on a square.
+//
+// It was used to generate rookMagicNumbers and bishopMagicNumbers.
+// The search is deterministic: each rank has its own seed.
+func findMagic(sq Square, slowAttacks func(Square, bitboard) bitboard) bitboard {
+	seeds := [8]uint64{728, 10316, 55013, 32803, 12281, 15100, 16645, 255}
+	rng := xorshift(seeds[sq.Rank()/8])
+	mask := magicMask(sq, slowAttacks)
+
+	for {
+		// sparse random numbers make better magic candidates
+		number := rng.next() & rng.next() & rng.next()
+		if ((mask * number) >> 56).ones() < 6 {
+			continue
+		}
+
+		if isMagic(sq, number, slowAttacks) {
+			return number
+		}
+	}
+}
+
+// isMagic checks whether the number maps every occupancy of the mask
+// to an index holding its attacks.
+func isMagic(sq Square, number bitboard, slowAttacks func(Square, bitboard) bitboard) bool {
+	mask := magicMask(sq, slowAttacks)
+	m := magic{mask: mask, magic: number, shift: uint8(64 - mask.ones())}
+	attacks := make([]bitboard, 1<<mask.ones())
+	used := make([]bool, 1<<mask.ones())
+
+	for _, occupied := range magicOccupancies(mask) {
+		idx, bb := m.index(occupied), slowAttacks(sq, occupied)
+		if used[idx] && attacks[idx] != bb {
+			return false
+		}
+		attacks[idx], used[idx] = bb, true
+	}
+
+	return true
+}
+
+// xorshift is a xorshift64* pseudo-random number generator.
+type xorshift uint64
+
+func (r *xorshift) next() bitboard {
+	*r ^= *r >> 12
+	*r ^= *r << 25
+	*r ^= *r >> 27
+	return bitboard(*r * 2685821657736338717)
+}
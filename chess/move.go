@@ -39,12 +39,14 @@ type Move uint32
 
 func newMove(p1, p2 Piece, s1, s2, enPassant Square, promo Piece) Move {
 	var tags MoveTag
-	if pt := p1.Type(); pt == King {
-		if (s1 == E1 && s2 == G1) || (s1 == E8 && s2 == G8) {
+	if pt := p1.Type(); pt == King && p2 != NoPiece && p2.Color() == p1.Color() {
+		// castling moves are encoded as the king capturing its own rook
+		if s2 > s1 {
 			tags |= KingSideCastle
-		} else if (s1 == E1 && s2 == C1) || (s1 == E8 && s2 == C8) {
+		} else {
 			tags |= QueenSideCastle
 		}
+		p2 = NoPiece
 	} else if pt == Pawn && s2 == enPassant {
 		tags |= EnPassant
 		tags |= Capture
@@ -97,7 +99,14 @@ func (m Move) HasTag(tag MoveTag) bool {
 
 // String implements the Stringer interface.
 // Returns the move in UCI notation.
+//
+// Castling moves are written as the king's two squares move (e1g1).
 func (m Move) String() string {
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		king, _ := castleDestinations(m)
+		return m.S1().String() + king.String()
+	}
+
 	base := m.S1().String() + m.S2().String()
 	if promo := m.Promo(); promo != NoPiece {
 		base += promo.Type().String()
@@ -105,7 +114,20 @@ func (m Move) String() string {
 	return base
 }
 
+// Chess960String returns the move in UCI notation as expected in Chess960
+// mode, where castling moves are written as the king capturing its own
+// rook (e1h1). Other moves are written as with String.
+func (m Move) Chess960String() string {
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		return m.S1().String() + m.S2().String()
+	}
+	return m.String()
+}
+
 // MoveFromUCI creates a move from a string in UCI notation.
+//
+// Castling moves are accepted both as the king's two squares move (e1g1)
+// and as the king capturing its own rook (e1h1).
 func MoveFromUCI(pos *Position, s string) (Move, error) {
 	if pos == nil {
 		return 0, errMissingPosition
@@ -139,6 +161,29 @@ func MoveFromUCI(pos *Position, s string) (Move, error) {
 	}
 
 	p1 := pos.board.pieceAt(s1)
+	if rook, ok := castleRookFromUCI(pos, p1, s1, s2); ok {
+		s2 = rook
+	}
+
 	p2 := pos.board.pieceAt(s2)
 	return newMove(p1, p2, s1, s2, pos.enPassant, promo), nil
 }
+
+// castleRookFromUCI returns the rook square of a castling move written as
+// the king's two squares move.
+func castleRookFromUCI(pos *Position, p1 Piece, s1, s2 Square) (Square, bool) {
+	if p1.Type() != King || s1.Rank() != s2.Rank() ||
+		s1.File()+1 >= s2.File() && s2.File()+1 >= s1.File() {
+		return NoSquare, false
+	}
+
+	c := p1.Color()
+	switch {
+	case s2.File() == FileG && pos.castlingRights.CanCastle(c, KingSide):
+		return pos.castleRooks[castleIndex(c, KingSide)], true
+	case s2.File() == FileC && pos.castlingRights.CanCastle(c, QueenSide):
+		return pos.castleRooks[castleIndex(c, QueenSide)], true
+	default:
+		return NoSquare, false
+	}
+}
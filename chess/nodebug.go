@@ -0,0 +1,8 @@
+//go:build !chessdebug
+
+package chess
+
+// debug enables the internal consistency checks of the package.
+const debug = false
+
+func (pos *Position) checkHash() {}
@@ -0,0 +1,45 @@
+package chess
+
+// Perft walks the move generation tree of legal moves up to the given depth
+// and returns the number of leaf nodes.
+//
+// Perft is a debugging function, the results can be compared to known
+// node counts in order to validate the move generator.
+//
+// A negative depth counts no leaf nodes.
+func Perft(pos *Position, depth int) int {
+	switch {
+	case depth < 0:
+		return 0
+	case depth == 0:
+		return 1
+	case depth == 1: // bulk counting
+		return len(legalMoves(pos))
+	}
+
+	var nodes int
+	for _, m := range pseudoMoves(pos) {
+		if meta, ok := pos.MakeMove(m); ok {
+			nodes += Perft(pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+	return nodes
+}
+
+// PerftDivide performs a perft and returns the number of leaf nodes
+// for each of the legal moves of the position.
+func PerftDivide(pos *Position, depth int) map[Move]int {
+	divide := make(map[Move]int)
+	if depth < 1 {
+		return divide
+	}
+
+	for _, m := range pseudoMoves(pos) {
+		if meta, ok := pos.MakeMove(m); ok {
+			divide[m] = Perft(pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+	return divide
+}
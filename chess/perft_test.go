@@ -0,0 +1,161 @@
+package chess
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type perfTest struct {
+	name  string
+	fen   string
+	nodes []int // indexed by depth - 1
+}
+
+var perfResults = []perfTest{
+	{
+		"starting position",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		[]int{
+			20, 400, 8902, 197281, 4865609,
+			// 119060324, 3195901860, 84998978956, 2439530234167, 69352859712417
+		},
+	},
+	{
+		"kiwipete",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		[]int{
+			48, 2039, 97862, 4085603,
+			//  193690690
+		},
+	},
+	{
+		"position 3",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		[]int{
+			14, 191, 2812, 43238, 674624, 11030083,
+			//  178633661
+		},
+	},
+	{
+		"position 4",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		[]int{
+			6, 264, 9467, 422333, 15833292,
+			// 706045033
+		},
+	},
+	{
+		"position 4 mirrored",
+		"r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1",
+		[]int{
+			6, 264, 9467, 422333, 15833292,
+			// 706045033
+		},
+	},
+	{
+		"position 5",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		[]int{
+			44, 1486, 62379, 2103487, 89941194,
+		},
+	},
+	{
+		"position 6",
+		"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		[]int{
+			46, 2079, 89890, 3894594,
+			//  164075551, 6923051137, 287188994746, 11923589843526, 490154852788714
+		},
+	},
+	{
+		"chess960 position 1",
+		"bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w KQkq - 2 9",
+		[]int{21, 528, 12189, 326672, 8146062},
+	},
+	{
+		"chess960 position 2",
+		"2nnrbkr/p1qppppp/8/1ppb4/6PP/3PP3/PPP2P2/BQNNRBKR w KQkq - 1 9",
+		[]int{21, 807, 18002, 667366, 16253601},
+	},
+	{
+		"chess960 position 3",
+		"b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w KQ - 1 9",
+		[]int{20, 479, 10471, 273318, 6417013},
+	},
+	{
+		"chess960 position 4",
+		"qbbnnrkr/2pp2pp/p7/1p2pp2/8/P3PP2/1PPP1KPP/QBBNNR1R w kq - 0 9",
+		[]int{22, 593, 13440, 382958, 9183776},
+	},
+	{
+		"chess960 position 5",
+		"1nbbnrkr/p1p1ppp1/3p4/1p3P1p/3Pq2P/8/PPP1P1P1/QNBBNRKR w KQkq - 0 9",
+		[]int{28, 1120, 31058, 1171749, 34030312},
+	},
+}
+
+func TestPerft(t *testing.T) {
+	for _, tt := range perfResults {
+		for depth := 1; depth <= len(tt.nodes); depth++ {
+			want := tt.nodes[depth-1]
+
+			if !testing.Short() || want < 2<<22 {
+				t.Run(fmt.Sprintf("%s depth %d", tt.name, depth), func(t *testing.T) {
+					got := Perft(unsafeFEN(tt.fen), depth)
+					assert.Equal(t, want, got)
+				})
+			}
+		}
+	}
+}
+
+func TestPerft_Depth(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth int
+		want  int
+	}{
+		{"negative", -1, 0},
+		{"zero", 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Perft(StartingPosition(), tt.depth))
+		})
+	}
+}
+
+func TestPerftDivide(t *testing.T) {
+	for _, tt := range perfResults {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			divide := PerftDivide(pos, 3)
+
+			var sum int
+			for m, nodes := range divide {
+				sum += nodes
+				meta, ok := pos.MakeMove(m)
+				assert.True(t, ok)
+				assert.Equal(t, Perft(pos, 2), nodes)
+				pos.UnmakeMove(m, meta)
+			}
+
+			assert.Len(t, divide, tt.nodes[0])
+			assert.Equal(t, tt.nodes[2], sum)
+		})
+	}
+}
+
+func BenchmarkPerft(b *testing.B) {
+	for _, bb := range perfResults {
+		pos := unsafeFEN(bb.fen)
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				Perft(pos, 3)
+			}
+		})
+	}
+}
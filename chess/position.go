@@ -10,9 +10,13 @@ type Position struct {
 	board
 	turn           Color
 	castlingRights CastlingRights
+	castleRooks    castleRooks
 	enPassant      Square
 	halfMoveClock  uint8
 	fullMoves      uint8
+	hash           uint64
+	pawnHash       uint64
+	materialHash   uint64
 }
 
 const startFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
@@ -26,6 +30,9 @@ func StartingPosition() *Position {
 }
 
 // FromFEN creates a Position from a FEN string.
+//
+// The castling field may use the X-FEN or Shredder-FEN notations
+// to describe Chess960 positions.
 func FromFEN(fen string) (*Position, error) {
 	fields := strings.Fields(strings.TrimSpace(fen))
 	if len(fields) != 6 {
@@ -45,7 +52,7 @@ func FromFEN(fen string) (*Position, error) {
 		return nil, err
 	}
 
-	pos.castlingRights, err = fenCastlingRights(fields[2])
+	pos.castlingRights, pos.castleRooks, err = fenCastlingRights(fields[2], pos.board)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +72,10 @@ func FromFEN(fen string) (*Position, error) {
 		return nil, err
 	}
 
+	pos.hash = zobristHash(pos)
+	pos.pawnHash = pawnZobristHash(pos.board)
+	pos.materialHash = materialZobristHash(pos.board)
+
 	return pos, nil
 }
 
@@ -108,7 +119,8 @@ func (pos Position) InCheck() bool {
 	return pos.getCheck(pos.turn.Other()) > 0
 }
 
-func (pos Position) String() string {
+// FEN returns the position in Forsyth-Edwards Notation.
+func (pos Position) FEN() string {
 	sq := "-"
 	if pos.enPassant != NoSquare {
 		sq = pos.enPassant.String()
@@ -118,13 +130,19 @@ func (pos Position) String() string {
 		"%s %s %s %s %d %d",
 		pos.board.String(),
 		pos.turn.String(),
-		pos.castlingRights.String(),
+		fenCastlingField(pos),
 		sq,
 		pos.halfMoveClock,
 		pos.fullMoves,
 	)
 }
 
+// String implements the Stringer interface.
+// Returns the position in Forsyth-Edwards Notation.
+func (pos Position) String() string {
+	return pos.FEN()
+}
+
 // PseudoMoves returns a list of pseudo moves.
 //
 // If the current player is not in check, some of the moves may not be legal.
@@ -133,12 +151,60 @@ func (pos *Position) PseudoMoves() []Move {
 	return pseudoMoves(pos)
 }
 
+// CaptureMoves returns the pseudo moves that capture a piece or promote a pawn,
+// including en passant captures and promotions without capture.
+//
+// Like PseudoMoves, only evasions are returned when the current player is in check.
+// Together with QuietMoves, it returns the same moves as PseudoMoves.
+func (pos *Position) CaptureMoves() []Move {
+	return generateMoves(pos, captureMoves)
+}
+
+// QuietMoves returns the pseudo moves that neither capture a piece nor promote a pawn,
+// including castling moves.
+//
+// Like PseudoMoves, only evasions are returned when the current player is in check.
+// Together with CaptureMoves, it returns the same moves as PseudoMoves.
+func (pos *Position) QuietMoves() []Move {
+	return generateMoves(pos, quietMoves)
+}
+
+// QuietChecks returns the quiet pseudo moves that give check,
+// directly or by discovery.
+func (pos *Position) QuietChecks() []Move {
+	return quietChecks(pos)
+}
+
+// LegalMoves returns the list of legal moves.
+//
+// Unlike PseudoMoves, all of the moves are guaranteed to be legal.
+func (pos *Position) LegalMoves() []Move {
+	return legalMoves(pos)
+}
+
+// HasLegalMoves indicates whether the current player has at least one legal move.
+//
+// It is cheaper than LegalMoves as it stops at the first legal move found.
+func (pos *Position) HasLegalMoves() bool {
+	return hasLegalMoves(pos)
+}
+
+// LegalMoveCount returns the number of legal moves.
+//
+// The legal moves are counted without being collected, but the pseudo moves
+// are still generated, which allocates. Use HasLegalMoves to only check
+// whether the current player can move.
+func (pos *Position) LegalMoveCount() int {
+	return legalMoveCount(pos)
+}
+
 // MakeMove makes a move on a position and checks whether it is valid.
 // Returns metadata that can be used to unmake the move and a boolean
 // indicating the validity of the move.
 func (pos *Position) MakeMove(m Move) (Metadata, bool) {
 	metadata := newMetadata(pos.turn, pos.castlingRights,
 		pos.halfMoveClock, pos.fullMoves, pos.enPassant)
+	hash := pos.hash ^ enPassantHash(pos)
 
 	if (m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle)) && !isCastleLegal(pos, m) {
 		return metadata, false
@@ -151,7 +217,7 @@ func (pos *Position) MakeMove(m Move) (Metadata, bool) {
 	}
 
 	pos.turn = pos.turn.Other()
-	pos.castlingRights = moveCastlingRights(pos.castlingRights, m)
+	pos.castlingRights = moveCastlingRights(pos.castlingRights, pos.castleRooks, m)
 	pos.enPassant = moveEnPassantMove(m)
 
 	if m.P1().Type() == Pawn || m.HasTag(Capture) {
@@ -164,17 +230,84 @@ func (pos *Position) MakeMove(m Move) (Metadata, bool) {
 		pos.fullMoves++
 	}
 
+	pos.hash = hash ^ enPassantHash(pos) ^ moveHash(m) ^
+		castleHash(metadata.castleRights()^pos.castlingRights) ^
+		polyRandom[polyRandomTurnOffset]
+	pos.pawnHash ^= pawnMoveHash(m)
+	pos.materialHash ^= materialMoveHash(pos.board, m)
+
+	if debug {
+		pos.checkHash()
+	}
+
 	return metadata, true
 }
 
 // UnmakeMove unmakes a move and restores the previous position.
 func (pos *Position) UnmakeMove(m Move, meta Metadata) {
+	hash := pos.hash ^ enPassantHash(pos) ^ moveHash(m) ^
+		castleHash(meta.castleRights()^pos.castlingRights) ^
+		polyRandom[polyRandomTurnOffset]
+	pos.pawnHash ^= pawnMoveHash(m)
+	pos.materialHash ^= materialMoveHash(pos.board, m)
+
 	pos.board.makeMoveBoard(m)
 	pos.turn = meta.turn()
 	pos.castlingRights = meta.castleRights()
 	pos.enPassant = meta.enPassant()
 	pos.halfMoveClock = meta.halfMoveClock()
 	pos.fullMoves = meta.fullMoves()
+	pos.hash = hash ^ enPassantHash(pos)
+
+	if debug {
+		pos.checkHash()
+	}
+}
+
+// MakeNullMove passes the turn without moving a piece, as used by
+// null move pruning and threat detection.
+//
+// Returns metadata that can be used to unmake the null move and a boolean
+// indicating its validity: the turn cannot be passed while in check, in which
+// case the position is left unchanged.
+func (pos *Position) MakeNullMove() (Metadata, bool) {
+	metadata := newMetadata(pos.turn, pos.castlingRights,
+		pos.halfMoveClock, pos.fullMoves, pos.enPassant)
+
+	if pos.InCheck() {
+		return metadata, false
+	}
+
+	hash := pos.hash ^ enPassantHash(pos)
+
+	pos.turn = pos.turn.Other()
+	pos.enPassant = NoSquare
+	pos.halfMoveClock++
+
+	if pos.turn == White {
+		pos.fullMoves++
+	}
+
+	pos.hash = hash ^ polyRandom[polyRandomTurnOffset]
+
+	if debug {
+		pos.checkHash()
+	}
+
+	return metadata, true
+}
+
+// UnmakeNullMove unmakes a null move and restores the previous position.
+func (pos *Position) UnmakeNullMove(meta Metadata) {
+	pos.turn = meta.turn()
+	pos.enPassant = meta.enPassant()
+	pos.halfMoveClock = meta.halfMoveClock()
+	pos.fullMoves = meta.fullMoves()
+	pos.hash ^= polyRandom[polyRandomTurnOffset] ^ enPassantHash(pos)
+
+	if debug {
+		pos.checkHash()
+	}
 }
 
 // PieceMap executes the callback for each piece on the board, passing
@@ -191,9 +324,27 @@ func (pos *Position) PieceMap(cb func(p Piece, sq Square)) {
 
 // Hash returns a Zobrist hash of the position.
 //
-// The hash is compatible with polyglot files.
+// The hash is compatible with polyglot files. It is updated incrementally
+// when moves are made and unmade.
 func (pos *Position) Hash() uint64 {
-	return zobristHash(pos)
+	return pos.hash
+}
+
+// PawnHash returns a Zobrist hash of the pawns of the position.
+//
+// Positions with the same pawn structure have the same pawn hash, which is
+// intended to be used as the key of a pawn hash table.
+func (pos *Position) PawnHash() uint64 {
+	return pos.pawnHash
+}
+
+// MaterialHash returns a hash of the material of the position.
+//
+// Positions with the same number of pieces of each kind have the same
+// material hash. It can be compared with the hash returned by the
+// MaterialHash function to recognize an endgame.
+func (pos *Position) MaterialHash() uint64 {
+	return pos.materialHash
 }
 
 // Copy returns a copy of the position.
@@ -202,29 +353,37 @@ func (pos *Position) Copy() *Position {
 		board:          pos.board.copyBoard(),
 		turn:           pos.turn,
 		castlingRights: pos.castlingRights,
+		castleRooks:    pos.castleRooks,
 		enPassant:      pos.enPassant,
 		halfMoveClock:  pos.halfMoveClock,
 		fullMoves:      pos.fullMoves,
+		hash:           pos.hash,
+		pawnHash:       pos.pawnHash,
+		materialHash:   pos.materialHash,
 	}
 }
 
-func moveCastlingRights(cr CastlingRights, m Move) CastlingRights {
-	switch p1, s1, s2 := m.P1(), m.S1(), m.S2(); {
-	case p1 == WhiteKing:
-		return cr & ^(CastleWhiteKing | CastleWhiteQueen)
-	case p1 == BlackKing:
-		return cr & ^(CastleBlackKing | CastleBlackQueen)
-	case (p1 == WhiteRook && s1 == A1) || s2 == A1:
-		return cr & ^CastleWhiteQueen
-	case (p1 == WhiteRook && s1 == H1) || s2 == H1:
-		return cr & ^CastleWhiteKing
-	case (p1 == BlackRook && s1 == A8) || s2 == A8:
-		return cr & ^CastleBlackQueen
-	case (p1 == BlackRook && s1 == H8) || s2 == H8:
-		return cr & ^CastleBlackKing
-	default:
+// moveCastlingRights removes the castling rights lost by a move: both rights
+// of a king that moves, and the right of a rook that moves or is captured.
+func moveCastlingRights(cr CastlingRights, rooks castleRooks, m Move) CastlingRights {
+	if cr == 0 {
 		return cr
 	}
+
+	switch m.P1() {
+	case WhiteKing:
+		cr &= ^(CastleWhiteKing | CastleWhiteQueen)
+	case BlackKing:
+		cr &= ^(CastleBlackKing | CastleBlackQueen)
+	}
+
+	for i, sq := range rooks {
+		if m.S1() == sq || m.S2() == sq {
+			cr &= ^CastlingRights(1 << i)
+		}
+	}
+
+	return cr
 }
 
 func moveEnPassantMove(m Move) Square {
@@ -50,6 +50,58 @@ func BenchmarkPosition_MakeMove(b *testing.B) {
 	}
 }
 
+func TestPosition_MakeNullMove(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+		ok   bool
+	}{
+		{
+			"white to move",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 1 1",
+			true,
+		},
+		{
+			"black to move with en passant",
+			"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3",
+			"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR b KQkq - 1 3",
+			true,
+		},
+		{
+			"black to move",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2",
+			true,
+		},
+		{
+			"in check",
+			"rnbqkbnr/ppppp1pp/8/5p1Q/4P3/8/PPPP1PPP/RNB1KBNR b KQkq - 1 2",
+			"rnbqkbnr/ppppp1pp/8/5p1Q/4P3/8/PPPP1PPP/RNB1KBNR b KQkq - 1 2",
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.args)
+			hash := pos.Hash()
+
+			meta, ok := pos.MakeNullMove()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, pos.String())
+			assert.Equal(t, unsafeFEN(tt.want).Hash(), pos.Hash())
+
+			if ok {
+				pos.UnmakeNullMove(meta)
+				assert.Equal(t, tt.args, pos.String())
+				assert.Equal(t, hash, pos.Hash())
+			}
+		})
+	}
+}
+
 func TestPosition_UnmakeMove(t *testing.T) {
 	for _, tt := range testPositions {
 		t.Run(tt.moveUCI, func(t *testing.T) {
@@ -0,0 +1,148 @@
+package chess
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Result represents the result of the game a record was taken from.
+type Result uint8
+
+const (
+	// NoResult represents an unknown or ongoing game.
+	NoResult Result = iota
+	// WhiteWins represents a game won by white.
+	WhiteWins
+	// BlackWins represents a game won by black.
+	BlackWins
+	// Draw represents a drawn game.
+	Draw
+)
+
+var resultNames = [...]string{"*", "1-0", "0-1", "1/2-1/2"}
+
+// String implements the Stringer interface.
+// Returns the PGN result token.
+func (r Result) String() string {
+	if int(r) < len(resultNames) {
+		return resultNames[r]
+	}
+	return "unknown"
+}
+
+// Record represents a position of a dataset, as used by self-play and tuning.
+type Record struct {
+	Position *Position
+	Score    int16  // score in centipawns from the point of view of the side to move
+	Result   Result // result of the game
+	Move     Move   // best move, 0 if unknown
+}
+
+// RecordSize is the size in bytes of a record in binary format.
+//
+//	35 bytes
+//	position 30 bytes  Position in binary format
+//	score     2 bytes  signed score
+//	result    1 byte   Result
+//	move      2 bytes  packed move
+//	bits 0-5   from square
+//	bits 6-11  to square, rook square for castling moves
+//	bits 12-15 promo piece
+//
+// Multi-byte fields are big-endian.
+const RecordSize = PositionSize + 5
+
+var errInvalidRecord = errors.New("invalid binary record")
+
+// RecordWriter writes records in binary format.
+//
+// Each record is written with a single call to the underlying writer,
+// which should be buffered when writing many records.
+type RecordWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewRecordWriter returns a new RecordWriter writing to w.
+func NewRecordWriter(w io.Writer) *RecordWriter {
+	return &RecordWriter{w: w, buf: make([]byte, 0, RecordSize)}
+}
+
+// Write writes a record.
+func (rw *RecordWriter) Write(r Record) error {
+	buf, err := r.Position.appendBinary(rw.buf[:0])
+	if err != nil {
+		return err
+	}
+
+	buf = binary.BigEndian.AppendUint16(buf, uint16(r.Score))
+	buf = append(buf, byte(r.Result))
+	buf = binary.BigEndian.AppendUint16(buf, encodeRecordMove(r.Move))
+
+	_, err = rw.w.Write(buf)
+	return err
+}
+
+// RecordReader reads records in binary format.
+type RecordReader struct {
+	r   io.Reader
+	buf [RecordSize]byte
+}
+
+// NewRecordReader returns a new RecordReader reading from r.
+func NewRecordReader(r io.Reader) *RecordReader {
+	return &RecordReader{r: r}
+}
+
+// Read reads the next record.
+//
+// Returns io.EOF when there are no more records, and io.ErrUnexpectedEOF
+// if the input ends in the middle of a record.
+func (rr *RecordReader) Read() (Record, error) {
+	if _, err := io.ReadFull(rr.r, rr.buf[:]); err != nil {
+		return Record{}, err
+	}
+
+	pos := &Position{}
+	if err := pos.UnmarshalBinary(rr.buf[:PositionSize]); err != nil {
+		return Record{}, err
+	}
+
+	data := rr.buf[PositionSize:]
+	m, err := decodeRecordMove(pos, binary.BigEndian.Uint16(data[3:5]))
+	if err != nil {
+		return Record{}, err
+	}
+
+	return Record{
+		Position: pos,
+		Score:    int16(binary.BigEndian.Uint16(data[0:2])),
+		Result:   Result(data[2]),
+		Move:     m,
+	}, nil
+}
+
+// encodeRecordMove returns the move packed in 16 bits.
+// The move is decoded from the squares and the position.
+func encodeRecordMove(m Move) uint16 {
+	if m == 0 {
+		return 0
+	}
+	return uint16(m.S1()) | uint16(m.S2())<<6 | uint16(m.Promo()&15)<<12
+}
+
+// decodeRecordMove returns the move packed by encodeRecordMove.
+func decodeRecordMove(pos *Position, data uint16) (Move, error) {
+	if data == 0 {
+		return 0, nil
+	}
+
+	s1, s2, promo := Square(data&63), Square((data>>6)&63), Piece(data>>12)
+	p1 := pos.board.pieceAt(s1)
+	if p1 == NoPiece || p1.Color() != pos.turn || promo > NoPiece {
+		return 0, errInvalidRecord
+	}
+
+	return newMove(p1, pos.board.pieceAt(s2), s1, s2, pos.enPassant, promo), nil
+}
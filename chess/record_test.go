@@ -0,0 +1,79 @@
+package chess
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	records := []struct {
+		fen    string
+		score  int16
+		result Result
+		move   string
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 30, NoResult, "e2e4"},
+		{"rnbqkbnr/ppp1pppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 3", -12, Draw, "e5d6"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 150, WhiteWins, "e1g1"},
+		{"1r2kr2/8/8/8/8/8/8/1R2KR2 b FBfb - 0 1", 0, BlackWins, "e8b8"},
+		{"n1n5/PPPk4/8/8/8/8/4Kppp/5N1N b - - 0 1", -32000, BlackWins, "g2h1q"},
+		{"8/8/3k4/8/8/4K3/8/R7 w - - 0 1", 1000, WhiteWins, ""},
+	}
+
+	var buf bytes.Buffer
+	w := NewRecordWriter(&buf)
+	var want []Record
+	for _, tt := range records {
+		pos := unsafeFEN(tt.fen)
+		var m Move
+		if tt.move != "" {
+			m, _ = MoveFromUCI(pos, tt.move)
+		}
+
+		r := Record{Position: pos, Score: tt.score, Result: tt.result, Move: m}
+		assert.Nil(t, w.Write(r))
+		want = append(want, r)
+	}
+
+	assert.Equal(t, len(records)*RecordSize, buf.Len())
+
+	r := NewRecordReader(&buf)
+	for _, record := range want {
+		got, err := r.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, record, got)
+	}
+
+	_, err := r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestRecordReader_Truncated(t *testing.T) {
+	var buf bytes.Buffer
+	_ = NewRecordWriter(&buf).Write(Record{Position: StartingPosition()})
+
+	r := NewRecordReader(bytes.NewReader(buf.Bytes()[:RecordSize-1]))
+	_, err := r.Read()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestRecordReader_InvalidMove(t *testing.T) {
+	var buf bytes.Buffer
+	pos := StartingPosition()
+	m, _ := MoveFromUCI(pos, "e7e5")
+	_ = NewRecordWriter(&buf).Write(Record{Position: pos, Move: m})
+
+	_, err := NewRecordReader(&buf).Read()
+	assert.Equal(t, errInvalidRecord, err)
+}
+
+func TestResult_String(t *testing.T) {
+	assert.Equal(t, "*", NoResult.String())
+	assert.Equal(t, "1-0", WhiteWins.String())
+	assert.Equal(t, "0-1", BlackWins.String())
+	assert.Equal(t, "1/2-1/2", Draw.String())
+	assert.Equal(t, "unknown", Result(4).String())
+}
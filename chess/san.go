@@ -0,0 +1,247 @@
+package chess
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	errInvalidSAN   = errors.New("invalid move in SAN notation")
+	errIllegalSAN   = errors.New("illegal move in SAN notation")
+	errAmbiguousSAN = errors.New("ambiguous move in SAN notation")
+)
+
+const sanPieceName = "PNBRQK"
+
+// sanPieceTypes maps the uppercase letters used in SAN to piece types.
+var sanPieceTypes = map[byte]PieceType{
+	'N': Knight,
+	'B': Bishop,
+	'R': Rook,
+	'Q': Queen,
+	'K': King,
+}
+
+// SAN returns the move in Standard Algebraic Notation.
+//
+// The position must be the one in which the move is played. It is needed
+// to disambiguate the move and to compute the check and checkmate suffixes.
+// The position is left unchanged.
+func (m Move) SAN(pos *Position) string {
+	var sb strings.Builder
+	sb.WriteString(sanBase(m, legalMoves(pos)))
+
+	if meta, ok := pos.MakeMove(m); ok {
+		switch {
+		case pos.InCheck() && !hasLegalMoves(pos):
+			sb.WriteByte('#')
+		case pos.InCheck():
+			sb.WriteByte('+')
+		}
+		pos.UnmakeMove(m, meta)
+	}
+
+	return sb.String()
+}
+
+// MoveFromSAN creates a move from a string in Standard Algebraic Notation.
+//
+// Check and checkmate suffixes as well as annotations (!, ?) are ignored.
+// Castling accepts both the letter O and the digit 0. The move must
+// be legal in the position.
+func MoveFromSAN(pos *Position, s string) (Move, error) {
+	if pos == nil {
+		return 0, errMissingPosition
+	}
+
+	s = strings.TrimRight(s, "+#!?")
+	if len(s) < 2 {
+		return 0, errInvalidSAN
+	}
+
+	moves := legalMoves(pos)
+
+	switch strings.ReplaceAll(s, "0", "O") {
+	case "O-O":
+		return sanCastle(moves, KingSideCastle)
+	case "O-O-O":
+		return sanCastle(moves, QueenSideCastle)
+	}
+
+	san, err := parseSAN(s)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		found Move
+		count int
+	)
+	for _, m := range moves {
+		if san.match(m) {
+			found = m
+			count++
+		}
+	}
+
+	switch count {
+	case 0:
+		return 0, errIllegalSAN
+	case 1:
+		return found, nil
+	default:
+		return 0, errAmbiguousSAN
+	}
+}
+
+// sanBase returns the SAN of a move without the check and checkmate suffixes.
+func sanBase(m Move, moves []Move) string {
+	switch {
+	case m.HasTag(KingSideCastle):
+		return "O-O"
+	case m.HasTag(QueenSideCastle):
+		return "O-O-O"
+	}
+
+	var sb strings.Builder
+	s1, s2, pt := m.S1(), m.S2(), m.P1().Type()
+	capture := m.HasTag(Capture)
+
+	if pt == Pawn {
+		if capture {
+			sb.WriteString(s1.File().String())
+		}
+	} else {
+		sb.WriteByte(sanPieceName[pt/2])
+		sb.WriteString(sanDisambiguation(m, moves))
+	}
+
+	if capture {
+		sb.WriteByte('x')
+	}
+
+	sb.WriteString(s2.String())
+
+	if promo := m.Promo(); promo != NoPiece {
+		sb.WriteByte('=')
+		sb.WriteByte(sanPieceName[promo.Type()/2])
+	}
+
+	return sb.String()
+}
+
+// sanDisambiguation returns the origin file, rank or square needed to
+// distinguish the move from other moves of the same piece type to the
+// same destination.
+func sanDisambiguation(m Move, moves []Move) string {
+	var ambiguous, sameFile, sameRank bool
+	for _, other := range moves {
+		if other == m || other.P1() != m.P1() || other.S2() != m.S2() || other.S1() == m.S1() {
+			continue
+		}
+
+		ambiguous = true
+		if other.S1().File() == m.S1().File() {
+			sameFile = true
+		}
+		if other.S1().Rank() == m.S1().Rank() {
+			sameRank = true
+		}
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return m.S1().File().String()
+	case !sameRank:
+		return m.S1().Rank().String()
+	default:
+		return m.S1().String()
+	}
+}
+
+func sanCastle(moves []Move, tag MoveTag) (Move, error) {
+	for _, m := range moves {
+		if m.HasTag(tag) {
+			return m, nil
+		}
+	}
+	return 0, errIllegalSAN
+}
+
+// sanMove holds the components of a parsed SAN move.
+type sanMove struct {
+	pieceType PieceType
+	file      File
+	rank      Rank
+	hasFile   bool
+	hasRank   bool
+	s2        Square
+	promo     PieceType
+}
+
+// parseSAN parses a non castling SAN move stripped of its suffixes.
+func parseSAN(s string) (sanMove, error) {
+	san := sanMove{pieceType: Pawn, promo: NoPieceType}
+
+	if pt, ok := sanPieceTypes[s[0]]; ok {
+		san.pieceType = pt
+		s = s[1:]
+	}
+
+	// promotion, with or without the equal sign
+	if n := len(s); n > 0 {
+		if pt, ok := sanPieceTypes[s[n-1]]; ok && pt != King {
+			san.promo = pt
+			s = strings.TrimSuffix(s[:n-1], "=")
+		}
+	}
+
+	if len(s) < 2 {
+		return san, errInvalidSAN
+	}
+
+	s2, err := squareFromUCI(s[len(s)-2:])
+	if err != nil {
+		return san, errInvalidSAN
+	}
+	san.s2 = s2
+
+	origin := strings.TrimSuffix(s[:len(s)-2], "x")
+	for _, r := range origin {
+		switch {
+		case 'a' <= r && r <= 'h' && !san.hasFile:
+			san.file, san.hasFile = File(r-'a'), true
+		case '1' <= r && r <= '8' && !san.hasRank:
+			san.rank, san.hasRank = Rank(8*(r-'1')), true
+		default:
+			return san, errInvalidSAN
+		}
+	}
+
+	if san.promo != NoPieceType && san.pieceType != Pawn {
+		return san, errInvalidSAN
+	}
+
+	return san, nil
+}
+
+// match checks whether the move is described by the parsed SAN.
+func (san sanMove) match(m Move) bool {
+	if m.P1().Type() != san.pieceType || m.S2() != san.s2 {
+		return false
+	}
+
+	if san.hasFile && m.S1().File() != san.file ||
+		san.hasRank && m.S1().Rank() != san.rank {
+		return false
+	}
+
+	promo := NoPieceType
+	if m.Promo() != NoPiece {
+		promo = m.Promo().Type()
+	}
+
+	return promo == san.promo
+}
@@ -0,0 +1,124 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sanTests = []struct {
+	name string
+	fen  string
+	uci  string
+	san  string
+}{
+	{"pawn push", startFEN, "e2e4", "e4"},
+	{"knight", startFEN, "g1f3", "Nf3"},
+	{"pawn capture", "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", "e4d5", "exd5"},
+	{"en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", "e5f6", "exf6"},
+	{"king side castle", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", "O-O"},
+	{"queen side castle", "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8c8", "O-O-O"},
+	{"file disambiguation", "k7/8/8/8/8/5N2/8/1N5K w - - 0 1", "f3d2", "Nfd2"},
+	{"rank disambiguation", "7k/8/8/8/R7/8/R7/7K w - - 0 1", "a2a3", "R2a3"},
+	{"square disambiguation", "7k/8/8/8/Q1Q5/8/Q7/7K w - - 0 1", "a4b3", "Qa4b3"},
+	{"promotion", "8/P6k/8/8/8/8/8/K7 w - - 0 1", "a7a8q", "a8=Q"},
+	{"under promotion with capture", "1r5k/P7/8/8/8/8/8/K7 w - - 0 1", "a7b8n", "axb8=N"},
+	{"check", "k7/8/8/8/8/8/8/K6R w - - 0 1", "h1h8", "Rh8+"},
+	{"checkmate", "k7/8/1K6/8/8/8/8/7R w - - 0 1", "h1h8", "Rh8#"},
+	{"bishop capture", "r1bqkb1r/pp1n1ppp/2p1pn2/1B1p4/3P4/2N1PN2/PP3PPP/R1BQK2R w KQkq - 0 6", "b5c6", "Bxc6"},
+}
+
+func TestMove_SAN(t *testing.T) {
+	for _, tt := range sanTests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			m, err := MoveFromUCI(pos, tt.uci)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.san, m.SAN(pos))
+			assert.Equal(t, tt.fen, pos.String())
+		})
+	}
+}
+
+func TestMoveFromSAN(t *testing.T) {
+	for _, tt := range sanTests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			m, err := MoveFromSAN(pos, tt.san)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.uci, m.String())
+		})
+	}
+}
+
+func TestMoveFromSAN_Lenient(t *testing.T) {
+	tests := []struct {
+		fen  string
+		san  string
+		want string
+	}{
+		{startFEN, "Nf3!?", "g1f3"},
+		{startFEN, "Ngf3", "g1f3"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "0-0-0", "e1c1"},
+		{"8/P6k/8/8/8/8/8/K7 w - - 0 1", "a8Q", "a7a8q"},
+		{"rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", "ed5", "e4d5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.san, func(t *testing.T) {
+			m, err := MoveFromSAN(unsafeFEN(tt.fen), tt.san)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, m.String())
+		})
+	}
+}
+
+func TestMoveFromSAN_Invalid(t *testing.T) {
+	tests := []struct {
+		fen  string
+		san  string
+		want error
+	}{
+		{startFEN, "", errInvalidSAN},
+		{startFEN, "Z", errInvalidSAN},
+		{startFEN, "Nz3", errInvalidSAN},
+		{startFEN, "e5", errIllegalSAN},
+		{startFEN, "O-O", errIllegalSAN},
+		{"7k/8/8/8/R7/8/R7/7K w - - 0 1", "Ra3", errAmbiguousSAN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.san, func(t *testing.T) {
+			_, err := MoveFromSAN(unsafeFEN(tt.fen), tt.san)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+
+	_, err := MoveFromSAN(nil, "e4")
+	assert.Equal(t, errMissingPosition, err)
+}
+
+func TestSAN_RoundTrip(t *testing.T) {
+	for _, tt := range perfResults {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			for _, m := range pos.LegalMoves() {
+				san := m.SAN(pos)
+				got, err := MoveFromSAN(pos, san)
+				assert.Nil(t, err, san)
+				assert.Equal(t, m, got, san)
+			}
+		})
+	}
+}
+
+func BenchmarkMove_SAN(b *testing.B) {
+	for _, bb := range testPositions {
+		pos := unsafeFEN(bb.preFEN)
+		b.Run(bb.moveUCI, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bb.move.SAN(pos)
+			}
+		})
+	}
+}
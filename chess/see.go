@@ -0,0 +1,174 @@
+package chess
+
+// seeValues are the piece values used by the static exchange evaluation.
+var seeValues = [13]int{
+	Pawn:   100,
+	Knight: 300,
+	Bishop: 300,
+	Rook:   500,
+	Queen:  900,
+	King:   20000,
+}
+
+// SEE returns the static exchange evaluation of a move: the material
+// balance, from the point of view of the current player, of the sequence
+// of captures on the destination square that follows the move.
+//
+// Each side captures with its least valuable piece first and may stop
+// capturing at any point. Sliders hidden behind other attackers are taken
+// into account, pins are not.
+func (pos *Position) SEE(m Move) int {
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		return 0
+	}
+
+	var gain [32]int
+	captured, occupied := seeCapture(pos, m)
+	gain[0] = captured
+	attacker := seeAttacker(m)
+	s2 := m.S2()
+	attackers := attackersBitboard(&pos.board, s2, occupied)
+
+	var d int
+	for c := pos.turn.Other(); ; c = c.Other() {
+		sq, pt := leastValuableAttacker(&pos.board, attackers, c)
+		if pt == NoPieceType {
+			break
+		}
+
+		d++
+		gain[d] = seeValues[attacker] - gain[d-1]
+		attacker = pt
+		occupied ^= sq.bitboard()
+		attackers = updateAttackersBitboard(&pos.board, s2, occupied, attackers)
+	}
+
+	// each side may stop capturing if it would lose material
+	for ; d > 0; d-- {
+		if gain[d] > -gain[d-1] {
+			gain[d-1] = -gain[d]
+		}
+	}
+
+	return gain[0]
+}
+
+// SEEGE reports whether the static exchange evaluation of a move
+// is greater than or equal to the threshold.
+//
+// It returns the same result as comparing SEE to the threshold,
+// but stops as soon as the outcome is known.
+func (pos *Position) SEEGE(m Move, threshold int) bool {
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		return threshold <= 0
+	}
+
+	gain, occupied := seeCapture(pos, m)
+	swap := gain - threshold
+	if swap < 0 {
+		return false
+	}
+
+	swap = seeValues[seeAttacker(m)] - swap
+	if swap <= 0 {
+		return true
+	}
+
+	s2 := m.S2()
+	attackers := attackersBitboard(&pos.board, s2, occupied)
+	res := 1
+
+	for c := pos.turn.Other(); ; c = c.Other() {
+		sq, pt := leastValuableAttacker(&pos.board, attackers, c)
+		if pt == NoPieceType {
+			break
+		}
+
+		res ^= 1
+
+		// the king cannot capture a defended piece
+		if pt == King {
+			if attackers&pos.getColor(c.Other()) > 0 {
+				res ^= 1
+			}
+			break
+		}
+
+		if swap = seeValues[pt] - swap; swap < res {
+			break
+		}
+
+		occupied ^= sq.bitboard()
+		attackers = updateAttackersBitboard(&pos.board, s2, occupied, attackers)
+	}
+
+	return res == 1
+}
+
+// seeCapture returns the material won by the move itself and the
+// occupancy once the move has been made.
+func seeCapture(pos *Position, m Move) (int, bitboard) {
+	occupied := pos.bbOccupied ^ m.S1().bitboard()
+	gain := seeValues[m.P2().Type()]
+
+	if m.HasTag(EnPassant) {
+		captured := m.S2() - 8
+		if pos.turn == Black {
+			captured = m.S2() + 8
+		}
+		occupied ^= captured.bitboard()
+		gain = seeValues[Pawn]
+	}
+
+	if promo := m.Promo(); promo != NoPiece {
+		gain += seeValues[promo.Type()] - seeValues[Pawn]
+	}
+
+	return gain, occupied
+}
+
+// seeAttacker returns the type of the piece standing on the destination
+// square after the move.
+func seeAttacker(m Move) PieceType {
+	if promo := m.Promo(); promo != NoPiece {
+		return promo.Type()
+	}
+	return m.P1().Type()
+}
+
+// attackersBitboard returns the pieces of both colors that attack
+// the square given an occupancy.
+func attackersBitboard(b *board, sq Square, occupied bitboard) bitboard {
+	bbAttackers := bbKnightMoves[sq] & b.bbKnight
+	bbAttackers |= bbKingMoves[sq] & b.bbKing
+	bbAttackers |= rookAttacksBitboard(sq, occupied) & (b.bbRook | b.bbQueen)
+	bbAttackers |= bishopAttacksBitboard(sq, occupied) & (b.bbBishop | b.bbQueen)
+	bbAttackers |= bbBlackPawnCaptures[sq] & b.bbPawn & b.bbWhite
+	bbAttackers |= bbWhitePawnCaptures[sq] & b.bbPawn & b.bbBlack
+	return bbAttackers & occupied
+}
+
+// updateAttackersBitboard adds the sliders revealed once a piece has been
+// removed from the occupancy and removes the pieces that left it.
+func updateAttackersBitboard(b *board, sq Square, occupied, attackers bitboard) bitboard {
+	attackers |= rookAttacksBitboard(sq, occupied) & (b.bbRook | b.bbQueen)
+	attackers |= bishopAttacksBitboard(sq, occupied) & (b.bbBishop | b.bbQueen)
+	return attackers & occupied
+}
+
+// leastValuableAttacker returns the square and type of the least valuable
+// piece of the color among the attackers, or NoPieceType if there is none.
+func leastValuableAttacker(b *board, attackers bitboard, c Color) (Square, PieceType) {
+	attackers &= b.getColor(c)
+	if attackers == 0 {
+		return NoSquare, NoPieceType
+	}
+
+	for pt := Pawn; pt <= King; pt += 2 {
+		if bb := attackers & b.getBitboard(pt.color(c)); bb > 0 {
+			return bb.scanForward(), pt
+		}
+	}
+
+	return NoSquare, NoPieceType
+}
@@ -0,0 +1,156 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_SEE(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		move string
+		want int
+	}{
+		{
+			"undefended pawn",
+			"1k6/8/8/3p4/8/8/8/3QK3 w - - 0 1",
+			"d1d5",
+			100,
+		},
+		{
+			"queen takes defended pawn",
+			"1k6/8/4p3/3p4/8/8/8/3QK3 w - - 0 1",
+			"d1d5",
+			-800,
+		},
+		{
+			"pawn takes queen",
+			"1k6/8/8/3q4/4P3/8/8/4K3 w - - 0 1",
+			"e4d5",
+			900,
+		},
+		{
+			"rook exchange",
+			"1k1r4/1pp4p/p7/4p3/8/P5P1/1PP4P/2K1R3 w - - 0 1",
+			"e1e5",
+			100,
+		},
+		{
+			"x-ray attackers",
+			"1k1r3q/1ppn3p/p4b2/4p3/8/P2N2P1/1PP1R1BP/2K1Q3 w - - 0 1",
+			"d3e5",
+			-200,
+		},
+		{
+			"x-ray defenders",
+			"4k3/4r3/8/4p3/8/3N4/8/4RRK1 w - - 0 1",
+			"d3e5",
+			100,
+		},
+		{
+			"king cannot capture a defended piece",
+			"8/8/3k4/4p3/8/3N4/8/4R1K1 w - - 0 1",
+			"d3e5",
+			100,
+		},
+		{
+			"king captures an undefended piece",
+			"8/8/8/4pk2/8/8/8/4R1K1 w - - 0 1",
+			"e1e5",
+			-400,
+		},
+		{
+			"en passant",
+			"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1",
+			"e5d6",
+			100,
+		},
+		{
+			"promotion",
+			"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1",
+			"b7b8q",
+			800,
+		},
+		{
+			"defended promotion",
+			"r3k3/1P6/8/8/8/8/8/4K3 w - - 0 1",
+			"b7b8q",
+			-100,
+		},
+		{
+			"quiet move to an attacked square",
+			"4k3/8/3p4/8/8/8/8/2B1K3 w - - 0 1",
+			"c1e3",
+			0,
+		},
+		{
+			"quiet move hanging a piece",
+			"4k3/8/3p4/8/8/8/8/1N2K3 w - - 0 1",
+			"b1c3",
+			0,
+		},
+		{
+			"castling",
+			"4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+			"e1g1",
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			m, err := MoveFromUCI(pos, tt.move)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, pos.SEE(m))
+				assert.True(t, pos.SEEGE(m, tt.want))
+				assert.False(t, pos.SEEGE(m, tt.want+1))
+			}
+		})
+	}
+}
+
+func TestPosition_SEEGE(t *testing.T) {
+	var walk func(t *testing.T, pos *Position, depth int)
+	walk = func(t *testing.T, pos *Position, depth int) {
+		for _, m := range pos.PseudoMoves() {
+			see := pos.SEE(m)
+			for _, threshold := range []int{-1000, -500, -200, -100, 0, 1, 100, 200, 500, 1000} {
+				assert.Equal(t, see >= threshold, pos.SEEGE(m, threshold), "%s %s %d", pos, m, threshold)
+			}
+		}
+
+		if depth == 0 {
+			return
+		}
+
+		for _, m := range pos.LegalMoves() {
+			meta, _ := pos.MakeMove(m)
+			walk(t, pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+	}
+
+	for _, tt := range perfResults {
+		t.Run(tt.name, func(t *testing.T) {
+			walk(t, unsafeFEN(tt.fen), 1)
+		})
+	}
+}
+
+func BenchmarkPosition_SEE(b *testing.B) {
+	pos := unsafeFEN("1k1r3q/1ppn3p/p4b2/4p3/8/P2N2P1/1PP1R1BP/2K1Q3 w - - 0 1")
+	m, _ := MoveFromUCI(pos, "d3e5")
+	b.Run("SEE", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			pos.SEE(m)
+		}
+	})
+	b.Run("SEEGE", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			pos.SEEGE(m, 0)
+		}
+	})
+}
@@ -80,7 +80,7 @@ const (
 )
 
 func fileFromUCI(r rune) (File, error) {
-	if !('a' <= r && r < 'h') {
+	if !('a' <= r && r <= 'h') {
 		return FileA, errInvalidFile
 	}
 	return fileMap[r-'a'], nil
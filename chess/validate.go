@@ -0,0 +1,104 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errInvalidPosition = errors.New("invalid position")
+
+// Validate checks whether the position could occur in a game.
+//
+// It rejects positions with a number of kings other than one per side,
+// pawns on the first or last rank, the side not to move in check, castling
+// rights without the king on its back rank and the rook on its original
+// square, castling rights with the rook on the wrong side of the king or the
+// king on a corner file, where it cannot stand between two rooks, and an en
+// passant square without the pawn that has just been pushed.
+func (pos *Position) Validate() error {
+	for _, c := range []Color{White, Black} {
+		if n := (pos.bbKing & pos.getColor(c)).ones(); n != 1 {
+			return fmt.Errorf("%w: %s has %d kings", errInvalidPosition, colorFullName(c), n)
+		}
+	}
+
+	if bb := pos.bbPawn & (bbRank1 | bbRank8); bb > 0 {
+		return fmt.Errorf("%w: pawn on %s", errInvalidPosition, bb.scanForward())
+	}
+
+	if pos.getCheck(pos.turn) > 0 {
+		return fmt.Errorf("%w: %s is in check but it is %s to move", errInvalidPosition,
+			colorFullName(pos.turn.Other()), colorFullName(pos.turn))
+	}
+
+	for i, rook := range pos.castleRooks {
+		right := CastlingRights(1 << i)
+		if pos.castlingRights&right == 0 {
+			continue
+		}
+
+		c := White
+		if rook.Rank() == Rank8 {
+			c = Black
+		}
+
+		if pos.getKingSquare(c).Rank() != rook.Rank() {
+			return fmt.Errorf("%w: castling right %s without king on rank %s",
+				errInvalidPosition, right, rook.Rank())
+		}
+
+		if pos.pieceAt(rook) != Rook.color(c) {
+			return fmt.Errorf("%w: castling right %s without rook on %s",
+				errInvalidPosition, right, rook)
+		}
+
+		king := pos.getKingSquare(c)
+		if f := king.File(); f == FileA || f == FileH {
+			return fmt.Errorf("%w: castling right %s with king on %s",
+				errInvalidPosition, right, king)
+		}
+
+		if kingSide := Side(i%2) == KingSide; kingSide != (rook.File() > king.File()) {
+			return fmt.Errorf("%w: castling right %s with rook on %s on the wrong side of the king",
+				errInvalidPosition, right, rook)
+		}
+	}
+
+	if err := pos.validateEnPassant(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateEnPassant checks that the en passant square is behind a pawn
+// that has just been pushed two squares.
+func (pos *Position) validateEnPassant() error {
+	ep := pos.enPassant
+	if ep == NoSquare {
+		return nil
+	}
+
+	rank, pushed, origin, pawn := Rank6, ep-8, ep+8, BlackPawn
+	if pos.turn == Black {
+		rank, pushed, origin, pawn = Rank3, ep+8, ep-8, WhitePawn
+	}
+
+	switch {
+	case ep.Rank() != rank:
+		return fmt.Errorf("%w: en passant square %s on the wrong rank", errInvalidPosition, ep)
+	case pos.pieceAt(pushed) != pawn:
+		return fmt.Errorf("%w: en passant square %s without a pawn on %s", errInvalidPosition, ep, pushed)
+	case pos.bbOccupied&(ep.bitboard()|origin.bitboard()) > 0:
+		return fmt.Errorf("%w: en passant square %s with occupied squares", errInvalidPosition, ep)
+	default:
+		return nil
+	}
+}
+
+func colorFullName(c Color) string {
+	if c == White {
+		return "white"
+	}
+	return "black"
+}
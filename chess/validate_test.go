@@ -0,0 +1,62 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want string
+	}{
+		{"starting position", startFEN, ""},
+		{"en passant", "rnbqkbnr/ppp1pppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", ""},
+		{"missing king", "8/8/8/4k3/8/8/8/8 w - - 0 1", "invalid position: white has 0 kings"},
+		{"extra king", "8/8/8/4k3/8/8/8/K3K3 w - - 0 1", "invalid position: white has 2 kings"},
+		{"pawn on the back rank", "4k2P/8/8/8/8/8/8/4K3 w - - 0 1", "invalid position: pawn on h8"},
+		{"side to move giving no check", "4k3/8/8/8/8/8/8/4KR2 w - - 0 1", ""},
+		{"side not to move in check", "4k3/8/8/8/8/8/8/4R1K1 w - - 0 1", "invalid position: black is in check but it is white to move"},
+		{"castling without rook", "r3k3/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "invalid position: castling right k without rook on h8"},
+		{"castling with moved king", "7k/8/8/8/8/8/4K3/R7 w Q - 0 1", "invalid position: castling right Q without king on rank 1"},
+		{"chess960 castling", "rk2r3/8/8/8/8/8/1P6/1R1KR2R w EBea - 0 1", ""},
+		{"castling with king on a corner file", "1k6/8/8/8/8/8/8/R6K w A - 0 1", "invalid position: castling right Q with king on h1"},
+		{"en passant on the wrong rank", "4k3/8/8/8/3p4/8/8/4K3 w - d3 0 1", "invalid position: en passant square d3 on the wrong rank"},
+		{"en passant without pawn", "4k3/8/8/8/8/8/8/4K3 w - d6 0 1", "invalid position: en passant square d6 without a pawn on d5"},
+		{"en passant with occupied squares", "4k3/3p4/8/3p4/8/8/8/4K3 w - d6 0 1", "invalid position: en passant square d6 with occupied squares"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := unsafeFEN(tt.fen).Validate()
+			if tt.want == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want)
+			assert.True(t, errors.Is(err, errInvalidPosition))
+		})
+	}
+}
+
+func TestPosition_Validate_CastleRookSide(t *testing.T) {
+	// the FEN parser always assigns a rook to the side it stands on,
+	// binary positions carry their rook squares as they are
+	pos := unsafeFEN("4k3/8/8/8/8/8/8/R3K2R w K - 0 1")
+	pos.castleRooks[castleIndex(White, KingSide)] = A1
+
+	err := pos.Validate()
+	assert.EqualError(t, err, "invalid position: castling right K with rook on a1 on the wrong side of the king")
+	assert.True(t, errors.Is(err, errInvalidPosition))
+}
+
+func TestPosition_FEN(t *testing.T) {
+	for _, tt := range perfResults {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fen, unsafeFEN(tt.fen).FEN())
+		})
+	}
+}
@@ -19,10 +19,145 @@ func zobristHash(pos *Position) (hash uint64) {
 	hash ^= pieceHash(pos)
 	hash ^= enPassantHash(pos)
 	hash ^= turnHash(pos)
-	hash ^= castleHash(pos)
+	hash ^= castleHash(pos.castlingRights)
 	return
 }
 
+// moveHash returns a hash (uint64)
+//
+// The hash is the exclusive or of the piece entries that change when the
+// move is made on the board: the moving piece on its origin and destination
+// squares, the captured piece and the rook of a castling move. Since the
+// exclusive or is its own inverse, the same hash is used to unmake the move.
+func moveHash(m Move) (hash uint64) {
+	p1, s1, s2 := m.P1(), m.S1(), m.S2()
+
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		king, rook := castleDestinations(m)
+		r := Rook.color(p1.Color())
+		return pieceSquareHash(p1, s1) ^ pieceSquareHash(p1, king) ^
+			pieceSquareHash(r, s2) ^ pieceSquareHash(r, rook)
+	}
+
+	hash ^= pieceSquareHash(p1, s1)
+
+	if promo := m.Promo(); promo != NoPiece {
+		hash ^= pieceSquareHash(promo, s2)
+	} else {
+		hash ^= pieceSquareHash(p1, s2)
+	}
+
+	switch {
+	case m.HasTag(EnPassant) && p1.Color() == White:
+		hash ^= pieceSquareHash(BlackPawn, s2-8)
+	case m.HasTag(EnPassant) && p1.Color() == Black:
+		hash ^= pieceSquareHash(WhitePawn, s2+8)
+	case m.HasTag(Capture):
+		hash ^= pieceSquareHash(m.P2(), s2)
+	}
+
+	return
+}
+
+// pawnZobristHash returns a hash (uint64)
+//
+// The hash is the exclusive or of the piece entries of the pawns of the board,
+// it identifies the pawn structure of a position.
+func pawnZobristHash(b board) (hash uint64) {
+	for p := BlackPawn; p <= WhitePawn; p++ {
+		for bb := b.getBitboard(p); bb > 0; bb = bb.resetLSB() {
+			hash ^= pieceSquareHash(p, bb.scanForward())
+		}
+	}
+	return
+}
+
+// pawnMoveHash returns a hash (uint64)
+//
+// The hash is the exclusive or of the pawn entries that change when the
+// move is made on the board. As with moveHash, the same hash is used to
+// unmake the move.
+func pawnMoveHash(m Move) (hash uint64) {
+	p1, s1, s2 := m.P1(), m.S1(), m.S2()
+
+	if p1.Type() == Pawn {
+		hash ^= pieceSquareHash(p1, s1)
+		if m.Promo() == NoPiece {
+			hash ^= pieceSquareHash(p1, s2)
+		}
+	}
+
+	switch {
+	case m.HasTag(EnPassant) && p1.Color() == White:
+		hash ^= pieceSquareHash(BlackPawn, s2-8)
+	case m.HasTag(EnPassant) && p1.Color() == Black:
+		hash ^= pieceSquareHash(WhitePawn, s2+8)
+	case m.HasTag(Capture) && m.P2().Type() == Pawn:
+		hash ^= pieceSquareHash(m.P2(), s2)
+	}
+
+	return
+}
+
+// materialZobristHash returns a hash (uint64)
+//
+// The hash only depends on the number of pieces of each kind on the board,
+// it identifies the material signature of a position. The n-th piece of a kind
+// uses the piece entry of the n-th square:
+//
+//	hash = piece(p, 0) ^ piece(p, 1) ^ ... ^ piece(p, count(p) - 1) ^ ...
+func materialZobristHash(b board) (hash uint64) {
+	for p := BlackPawn; p <= WhiteKing; p++ {
+		for i := 0; i < b.getBitboard(p).ones(); i++ {
+			hash ^= pieceSquareHash(p, Square(i))
+		}
+	}
+	return
+}
+
+// materialMoveHash returns a hash (uint64)
+//
+// The hash is the exclusive or of the material entries that change when the
+// move is made: the captured piece and the promoted pawn and piece. The board
+// is the board after the move, which makes the same hash usable to unmake it.
+func materialMoveHash(b board, m Move) (hash uint64) {
+	switch {
+	case m.HasTag(EnPassant):
+		p := Pawn.color(m.P1().Color().Other())
+		hash ^= pieceSquareHash(p, Square(b.getBitboard(p).ones()))
+	case m.HasTag(Capture):
+		p := m.P2()
+		hash ^= pieceSquareHash(p, Square(b.getBitboard(p).ones()))
+	}
+
+	if promo := m.Promo(); promo != NoPiece {
+		hash ^= pieceSquareHash(m.P1(), Square(b.getBitboard(m.P1()).ones()))
+		hash ^= pieceSquareHash(promo, Square(b.getBitboard(promo).ones()-1))
+	}
+
+	return
+}
+
+// MaterialHash returns the material hash of a set of pieces, to be compared
+// with the material hash of a position. For example, the material hash of
+// the king and rook versus king endgame with the rook on the white side is:
+//
+//	MaterialHash(WhiteKing, WhiteRook, BlackKing)
+func MaterialHash(pieces ...Piece) uint64 {
+	var b board
+	for i, p := range pieces {
+		// any distinct squares will do, only the counts matter
+		b.xorBitboard(p.Type(), Square(i).bitboard())
+		b.xorColor(p.Color(), Square(i).bitboard())
+	}
+	return materialZobristHash(b)
+}
+
+// pieceSquareHash returns the entry from randomPiece of a piece on a square.
+func pieceSquareHash(p Piece, sq Square) uint64 {
+	return polyRandom[64*uint16(p)+uint16(sq)]
+}
+
 // pieceHash returns a hash (uint64)
 //
 // The hash is the exclusive or of entries from randomPiece, one for each
@@ -49,23 +184,26 @@ func pieceHash(pos *Position) (hash uint64) {
 //
 // The hash is the the exclusive or of entries from randomCastle.
 // Entries are selected depending on which castle rights are available.
-// If the position has no castle rights, 0 is returned.
+// If there are no castle rights, 0 is returned.
 //
 //	white king side castle   0
 //	white queen side castle  1
 //	black king side castle   2
 //	black queen side castle  3
-func castleHash(pos *Position) (hash uint64) {
-	if pos.castlingRights.CanCastle(White, KingSide) {
+//
+// As each right has its own entry, the hash of the rights that changed
+// during a move is castleHash(before ^ after).
+func castleHash(cr CastlingRights) (hash uint64) {
+	if cr.CanCastle(White, KingSide) {
 		hash ^= polyRandom[polyRandomCastleOffset]
 	}
-	if pos.castlingRights.CanCastle(White, QueenSide) {
+	if cr.CanCastle(White, QueenSide) {
 		hash ^= polyRandom[polyRandomCastleOffset+1]
 	}
-	if pos.castlingRights.CanCastle(Black, KingSide) {
+	if cr.CanCastle(Black, KingSide) {
 		hash ^= polyRandom[polyRandomCastleOffset+2]
 	}
-	if pos.castlingRights.CanCastle(Black, QueenSide) {
+	if cr.CanCastle(Black, QueenSide) {
 		hash ^= polyRandom[polyRandomCastleOffset+3]
 	}
 	return
@@ -74,3 +74,139 @@ func BenchmarkZobristHash(b *testing.B) {
 		zobristHash(pos)
 	}
 }
+
+func TestPosition_Hash(t *testing.T) {
+	for _, tt := range perfResults {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			assertHashTree(t, pos, 3)
+		})
+	}
+}
+
+// assertHashTree walks the move tree and checks that the incremental hashes
+// equal the hashes computed from scratch after each move made and unmade.
+func assertHashTree(t *testing.T, pos *Position, depth int) {
+	if depth == 0 {
+		return
+	}
+
+	for _, m := range pos.PseudoMoves() {
+		before := [3]uint64{pos.Hash(), pos.PawnHash(), pos.MaterialHash()}
+		meta, ok := pos.MakeMove(m)
+		if ok {
+			assert.Equal(t, zobristHash(pos), pos.Hash(), "%s after %s", pos.String(), m.String())
+			assert.Equal(t, pawnZobristHash(pos.board), pos.PawnHash(), "%s after %s", pos.String(), m.String())
+			assert.Equal(t, materialZobristHash(pos.board), pos.MaterialHash(), "%s after %s", pos.String(), m.String())
+			assertHashTree(t, pos, depth-1)
+			pos.UnmakeMove(m, meta)
+		}
+		after := [3]uint64{pos.Hash(), pos.PawnHash(), pos.MaterialHash()}
+		assert.Equal(t, before, after, "%s after unmaking %s", pos.String(), m.String())
+	}
+}
+
+func TestPosition_PawnHash(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{
+			"same pawns, different pieces",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"4k3/pppppppp/8/8/4P3/8/PPPP1PPP/4K3 w - - 0 1",
+			true,
+		},
+		{
+			"different pawns",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			false,
+		},
+		{
+			"same squares, different colors",
+			"4k3/8/8/8/4p3/8/8/4K3 w - - 0 1",
+			"4k3/8/8/8/4P3/8/8/4K3 w - - 0 1",
+			false,
+		},
+		{
+			"no pawns",
+			"4k3/8/8/8/8/8/8/R3K3 w - - 0 1",
+			"4k3/8/8/8/8/8/8/4K2R w - - 0 1",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := unsafeFEN(tt.a), unsafeFEN(tt.b)
+			assert.Equal(t, tt.want, a.PawnHash() == b.PawnHash())
+		})
+	}
+}
+
+func TestPosition_MaterialHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		fen    string
+		pieces []Piece
+	}{
+		{"KK", "8/8/3k4/8/8/4K3/8/8 w - - 0 1", []Piece{WhiteKing, BlackKing}},
+		{"KRK", "8/8/3k4/8/8/4K3/8/R7 w - - 0 1", []Piece{WhiteKing, WhiteRook, BlackKing}},
+		{"KKR", "8/8/3k4/8/8/4K3/8/r7 w - - 0 1", []Piece{WhiteKing, BlackKing, BlackRook}},
+		{"KPK", "8/8/3k4/8/8/4K3/4P3/8 b - - 0 1", []Piece{WhitePawn, WhiteKing, BlackKing}},
+		{"KBNK", "8/8/3k4/8/8/2B1K3/8/6N1 w - - 0 1", []Piece{BlackKing, WhiteKnight, WhiteKing, WhiteBishop}},
+		{"KPPKP", "8/5p2/3k4/8/8/4K3/3PP3/8 w - - 0 1", []Piece{WhiteKing, WhitePawn, WhitePawn, BlackKing, BlackPawn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, MaterialHash(tt.pieces...), unsafeFEN(tt.fen).MaterialHash())
+		})
+	}
+
+	t.Run("distinct signatures", func(t *testing.T) {
+		seen := make(map[uint64]string)
+		for _, tt := range tests {
+			hash := MaterialHash(tt.pieces...)
+			assert.NotContains(t, seen, hash, "%s collides with %s", tt.name, seen[hash])
+			seen[hash] = tt.name
+		}
+	})
+}
+
+func TestPosition_Hash_Moves(t *testing.T) {
+	tests := []struct {
+		moves []string
+		want  string
+	}{
+		{[]string{"e2e4"}, "0x823c9b50fd114196"},
+		{[]string{"e2e4", "d7d5"}, "0x0756b94461c50fb0"},
+		{[]string{"e2e4", "d7d5", "e4e5"}, "0x662fafb965db29d4"},
+		{[]string{"e2e4", "d7d5", "e4e5", "f7f5"}, "0x22a48b5a8e47ff78"},
+		{[]string{"e2e4", "d7d5", "e4e5", "f7f5", "e1e2"}, "0x652a607ca3f242c1"},
+		{[]string{"e2e4", "d7d5", "e4e5", "f7f5", "e1e2", "e8f7"}, "0x00fdd303c946bdd9"},
+		{[]string{"a2a4", "b7b5", "h2h4", "b5b4", "c2c4"}, "0x3c8123ea7b067637"},
+		{[]string{"a2a4", "b7b5", "h2h4", "b5b4", "c2c4", "b4c3", "a1a3"}, "0x5c3f9b829b279560"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.moves), func(t *testing.T) {
+			pos := StartingPosition()
+			for _, uci := range tt.moves {
+				m, err := MoveFromUCI(pos, uci)
+				assert.Nil(t, err)
+				pos.MakeMove(m)
+			}
+			assert.Equal(t, tt.want, fmt.Sprintf("0x%016x", pos.Hash()))
+		})
+	}
+}
+
+func BenchmarkPosition_Hash(b *testing.B) {
+	pos := unsafeFEN("rnbqkbnr/p1pppppp/8/8/PpP4P/8/1P1PPPP1/RNBQKBNR b KQkq c3 0 3")
+	for n := 0; n < b.N; n++ {
+		pos.Hash()
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/leonhfr/honeybadger/chess"
+	"github.com/leonhfr/honeybadger/engine"
+	"github.com/leonhfr/honeybadger/uci"
+)
+
+// epdCmd represents the epd command.
+// It runs a test suite of positions in EPD format.
+var epdCmd = &cobra.Command{
+	Use:   "epd <file>",
+	Short: "Runs a test suite from an EPD file",
+	Long: `EPD runs a search on each record of an EPD file and checks the
+move found against the best moves (bm) and the moves to avoid (am) of
+the record.
+
+A record passes when the move found is one of the best moves and none
+of the moves to avoid, and fails when no move is found. A report line
+is printed for each record, followed by the number of records that passed.
+
+Each record is searched as a new game. Without a time limit, the search
+runs up to the depth, which must then be set.`,
+	Example: `  honeybadger epd wac.epd --time 5s
+  honeybadger epd ecm.epd --depth 6 --time 0 --OpeningStrategy None`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input, err := parseEPDInputFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		records, err := readEPD(args[0])
+		if err != nil {
+			return err
+		}
+
+		e := engine.New()
+		defer e.Quit()
+
+		for _, option := range parseEngineOptionsFlags(cmd) {
+			err := e.SetOption(option.name, option.value)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := e.Init(); err != nil {
+			return err
+		}
+
+		start := time.Now()
+
+		var passed int
+		for i, record := range records {
+			id := record.ID()
+			if id == "" {
+				id = fmt.Sprintf("#%d", i+1)
+			}
+
+			e.NewGame()
+			best, err := searchEPD(cmd, e, record, input)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+
+			ok, expected, err := checkEPD(record, best)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+
+			result := "fail"
+			if ok {
+				result = "pass"
+				passed++
+			}
+
+			found := "none"
+			if best != 0 {
+				found = best.SAN(record.Position)
+			}
+
+			fmt.Printf("%s %s found %s expected %s\n", id, result, found, expected)
+		}
+
+		fmt.Printf("\npassed %d/%d time %d\n", passed, len(records), time.Since(start).Milliseconds())
+
+		return nil
+	},
+}
+
+func init() {
+	epdCmd.Flags().SortFlags = false
+
+	// search options
+	epdCmd.Flags().IntP(depthFlag, "d", 64, "depth at which to search each position")
+	epdCmd.Flags().DurationP(timeFlag, "t", 5*time.Second, "limit search time of each position")
+
+	// engine options
+	addEngineOptionsFlags(epdCmd)
+}
+
+// readEPD reads the records of an EPD file, one per line.
+func readEPD(name string) ([]*chess.EPD, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*chess.EPD
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		record, err := chess.FromEPD(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// searchEPD searches the position of the record and returns the best move found,
+// or the zero move when the search found none.
+func searchEPD(cmd *cobra.Command, e *engine.Engine, record *chess.EPD, input uci.Input) (chess.Move, error) {
+	if err := e.SetPosition(record.Position.FEN()); err != nil {
+		return 0, err
+	}
+
+	oc, err := e.Search(cmd.Context(), input)
+	if err != nil {
+		return 0, err
+	}
+
+	var best string
+	for output := range oc {
+		if len(output.PV) > 0 {
+			best = output.PV[0]
+		}
+	}
+
+	if best == "" {
+		return 0, nil
+	}
+
+	return chess.MoveFromUCI(record.Position, best)
+}
+
+// checkEPD checks the move found against the best moves and the moves to avoid
+// of the record. Returns whether the record passed and the expectation.
+//
+// A record fails when no move was found.
+func checkEPD(record *chess.EPD, found chess.Move) (bool, string, error) {
+	bm, err := record.BestMoves()
+	if err != nil {
+		return false, "", err
+	}
+
+	am, err := record.AvoidMoves()
+	if err != nil {
+		return false, "", err
+	}
+
+	ok := found != 0 && (len(bm) == 0 || containsMove(bm, found))
+	ok = ok && !containsMove(am, found)
+
+	var expected []string
+	if len(bm) > 0 {
+		expected = append(expected, "bm "+sanMoves(record.Position, bm))
+	}
+	if len(am) > 0 {
+		expected = append(expected, "am "+sanMoves(record.Position, am))
+	}
+
+	return ok, strings.Join(expected, " "), nil
+}
+
+// parseEPDInputFlags returns the search input of each record.
+//
+// Without a time limit, the search only stops at the depth,
+// so the depth flag has to be set to a positive depth.
+func parseEPDInputFlags(cmd *cobra.Command) (uci.Input, error) {
+	depth, _ := cmd.Flags().GetInt(depthFlag)
+	time, _ := cmd.Flags().GetDuration(timeFlag)
+	input := uci.Input{
+		Depth:    depth,
+		MoveTime: time,
+	}
+
+	if time == 0 {
+		if !cmd.Flags().Changed(depthFlag) || depth < 1 {
+			return uci.Input{}, errors.New("a positive depth is required without a time limit")
+		}
+		input.Infinite = true
+	}
+
+	return input, nil
+}
+
+func containsMove(moves []chess.Move, m chess.Move) bool {
+	for _, move := range moves {
+		if move == m {
+			return true
+		}
+	}
+	return false
+}
+
+func sanMoves(pos *chess.Position, moves []chess.Move) string {
+	san := make([]string, 0, len(moves))
+	for _, m := range moves {
+		san = append(san, m.SAN(pos))
+	}
+	return strings.Join(san, " ")
+}
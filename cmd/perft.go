@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/leonhfr/honeybadger/chess"
+)
+
+const (
+	divideFlag = "divide"
+)
+
+// perftCmd represents the perft command.
+// It counts the leaf nodes of the move generation tree.
+var perftCmd = &cobra.Command{
+	Use:   "perft <fen>",
+	Short: "Runs a perft on a FEN",
+	Long: `Perft walks the move generation tree of legal moves up to the
+given depth and counts the leaf nodes.
+
+The divide option lists the number of leaf nodes for each legal move
+of the position. The results can be compared with other engines to
+debug move generation.`,
+	Example: `  honeybadger perft rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 --depth 5
+  honeybadger perft r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1 -d 3 --divide`,
+	Args: cobra.ExactArgs(6),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pos, err := chess.FromFEN(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if err := pos.Validate(); err != nil {
+			return err
+		}
+
+		depth, _ := cmd.Flags().GetInt(depthFlag)
+		if depth < 0 {
+			return fmt.Errorf("depth should not be negative, got %d", depth)
+		}
+		divide, _ := cmd.Flags().GetBool(divideFlag)
+
+		start := time.Now()
+		if !divide {
+			nodes := chess.Perft(pos, depth)
+			fmt.Printf("nodes %d time %d\n", nodes, time.Since(start).Milliseconds())
+			return nil
+		}
+
+		results := chess.PerftDivide(pos, depth)
+		moves := make([]chess.Move, 0, len(results))
+		for m := range results {
+			moves = append(moves, m)
+		}
+		sort.Slice(moves, func(i, j int) bool {
+			return moves[i].String() < moves[j].String()
+		})
+
+		var nodes int
+		for _, m := range moves {
+			nodes += results[m]
+			fmt.Printf("%s: %d\n", m, results[m])
+		}
+		fmt.Printf("\nmoves %d nodes %d time %d\n", len(moves), nodes, time.Since(start).Milliseconds())
+
+		return nil
+	},
+}
+
+func init() {
+	perftCmd.Flags().SortFlags = false
+
+	perftCmd.Flags().IntP(depthFlag, "d", 1, "depth of the perft")
+	perftCmd.Flags().Bool(divideFlag, false, "list the leaf nodes count of each move")
+}
@@ -84,16 +84,28 @@ func init() {
 	searchCmd.Flags().Bool(verboseFlag, false, "verbose")
 
 	// engine options
+	addEngineOptionsFlags(searchCmd)
+}
+
+// addEngineOptionsFlags adds a flag for each engine option, whatever its type.
+func addEngineOptionsFlags(cmd *cobra.Command) {
 	for _, option := range engine.New().Options() {
 		switch option.Type {
+		case uci.OptionBoolean:
+			addBooleanOption(cmd, option)
 		case uci.OptionInteger:
-			addIntegerOption(searchCmd, option)
+			addIntegerOption(cmd, option)
 		case uci.OptionEnum:
-			addEnumOption(searchCmd, option)
+			addEnumOption(cmd, option)
 		}
 	}
 }
 
+func addBooleanOption(cmd *cobra.Command, option uci.Option) {
+	value, _ := strconv.ParseBool(option.Default)
+	cmd.Flags().Bool(option.Name, value, "true or false")
+}
+
 func addIntegerOption(cmd *cobra.Command, option uci.Option) {
 	value, _ := strconv.ParseInt(option.Default, 10, 0)
 	cmd.Flags().Int(option.Name, int(value), fmt.Sprintf("from %s to %s", option.Min, option.Max))
@@ -132,6 +144,9 @@ func parseEngineOptionsFlags(cmd *cobra.Command) []engineOption {
 	var options []engineOption
 	for _, option := range engine.New().Options() {
 		switch option.Type {
+		case uci.OptionBoolean:
+			value, _ := cmd.Flags().GetBool(option.Name)
+			options = append(options, engineOption{option.Name, fmt.Sprint(value)})
 		case uci.OptionInteger:
 			value, _ := cmd.Flags().GetInt(option.Name)
 			options = append(options, engineOption{option.Name, fmt.Sprint(value)})
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ichess "github.com/leonhfr/honeybadger/chess"
+	searchv2 "github.com/leonhfr/honeybadger/search_v2"
+	"github.com/leonhfr/honeybadger/uci"
+)
+
+var errIllegalMove = errors.New("illegal move")
+
+// chess960SearchInfo is sent at the start of a search in Chess960 mode.
+const chess960SearchInfo = "Chess960 search with AlphaBetaV2, the configured strategies and the opening book are not used"
+
+// moveChess960 plays a move in UCI notation in Chess960 mode.
+//
+// The move is played on the internal game, which supports castling with any
+// king and rook files and accepts castling moves written as the king
+// capturing its own rook.
+func (e *Engine) moveChess960(move string) error {
+	m, err := ichess.MoveFromUCI(e.position.Position(), move)
+	if err != nil {
+		return err
+	}
+
+	if !isLegal(e.position.Position(), m) || !e.position.MakeMove(m) {
+		return errIllegalMove
+	}
+	return nil
+}
+
+// searchChess960 runs a search on the internal game in Chess960 mode.
+//
+// The game of the notnil/chess package only knows the castling squares of
+// standard chess, so the search is run by the search_v2 package on the
+// internal game instead, whose history is used to detect repetitions. The
+// search, evaluation, oracle, quiescence, transposition and opening
+// strategies are not used, which is reported in an info string.
+func (e *Engine) searchChess960(ctx context.Context, input uci.Input) <-chan uci.Output {
+	engineOutput := make(chan uci.Output)
+
+	e.mu.Lock()
+	start := time.Now()
+	ctx, cancel := searchContext(ctx, input, e.stopSearch)
+
+	searchMoves, err := searchMovesChess960(e.position.Position(), input.SearchMoves)
+	if err != nil {
+		e.log("could not parse search moves, defaulting to all possible moves", err)
+	}
+	searchOutput := searchv2.Run(ctx, searchv2.Input{
+		Game:        e.position,
+		SearchMoves: searchMoves,
+		Depth:       input.Depth,
+	})
+
+	go func() {
+		defer e.mu.Unlock()
+		defer cancel()
+		defer close(engineOutput)
+
+		engineOutput <- uci.Output{Info: chess960SearchInfo}
+		for output := range searchOutput {
+			var pv []string
+			for _, move := range output.PV {
+				pv = append(pv, move.Chess960String())
+			}
+
+			engineOutput <- uci.Output{
+				Time:  time.Since(start),
+				Depth: output.Depth,
+				Nodes: output.Nodes,
+				Score: output.Score,
+				Mate:  output.Mate,
+				PV:    pv,
+			}
+		}
+	}()
+
+	return engineOutput
+}
+
+// searchMovesChess960 decodes the search moves in UCI notation
+// on the internal position.
+func searchMovesChess960(pos *ichess.Position, moves []string) ([]ichess.Move, error) {
+	var searchMoves []ichess.Move
+	for _, move := range moves {
+		m, err := ichess.MoveFromUCI(pos, move)
+		if err != nil {
+			return nil, err
+		}
+		searchMoves = append(searchMoves, m)
+	}
+	return searchMoves, nil
+}
+
+// isLegal checks whether the move is one of the legal moves of the position.
+func isLegal(pos *ichess.Position, m ichess.Move) bool {
+	for _, legal := range pos.LegalMoves() {
+		if legal == m {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ package engine
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"sync"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/notnil/chess"
 
+	ichess "github.com/leonhfr/honeybadger/chess"
 	"github.com/leonhfr/honeybadger/evaluation"
 	"github.com/leonhfr/honeybadger/opening"
 	"github.com/leonhfr/honeybadger/opening/book"
@@ -37,7 +39,8 @@ type Engine struct {
 	author      string
 	debug       bool
 	logger      *log.Logger
-	game        *chess.Game
+	game        *chess.Game  // searched in standard mode
+	position    *ichess.Game // mirror of the game, searched in Chess960 mode
 	notation    chess.Notation
 	mu          sync.Mutex
 	once        sync.Once
@@ -47,13 +50,20 @@ type Engine struct {
 }
 
 type engineOptions struct {
-	search        search.Interface        // Search strategy.
-	evaluation    evaluation.Interface    // Evaluation strategy.
-	oracle        oracle.Interface        // Oracle strategy.
-	quiescence    quiescence.Interface    // Quiescence strategy.
-	transposition transposition.Interface // Transposition strategy.
-	opening       opening.Interface       // Opening strategy.
-	hash          int                     // Size of the transposition hash table in MB.
+	search             search.Interface        // Search strategy.
+	evaluation         evaluation.Interface    // Evaluation strategy.
+	oracle             oracle.Interface        // Oracle strategy.
+	quiescence         quiescence.Interface    // Quiescence strategy.
+	transposition      transposition.Interface // Transposition strategy.
+	opening            opening.Interface       // Opening strategy.
+	hash               int                     // Size of the transposition hash table in MB.
+	chess960           bool                    // Chess960 mode.
+	nullMove           bool                    // Null move pruning.
+	nullMoveVerify     bool                    // Verification of null move cutoffs.
+	lateMoveReductions bool                    // Late move reductions and pruning.
+	extensions         search.Extension        // Search extensions.
+	extensionBudget    int                     // Maximum plies of extension along a line.
+	margins            search.Margins          // Forward pruning margins.
 }
 
 // New returns a new Engine.
@@ -64,6 +74,7 @@ func New(options ...func(*Engine)) *Engine {
 	e := &Engine{
 		logger:     log.New(os.Stdout, "", 0),
 		game:       chess.NewGame(),
+		position:   ichess.NewGame(ichess.StartingPosition()),
 		notation:   chess.UCINotation{},
 		mu:         sync.Mutex{},
 		stopSearch: make(chan struct{}),
@@ -125,8 +136,15 @@ func WithEvaluation(ei evaluation.Interface) func(*Engine) {
 }
 
 // WithOracle sets the oracle strategy.
+//
+// The KillerHistory oracle is copied, so engines do not share what they learn.
 func WithOracle(oi oracle.Interface) func(*Engine) {
 	return func(e *Engine) {
+		if kh, ok := oi.(*oracle.KillerHistory); ok {
+			copied := *kh
+			e.options.oracle = &copied
+			return
+		}
 		e.options.oracle = oi
 	}
 }
@@ -159,6 +177,73 @@ func WithHash(hash int) func(*Engine) {
 	}
 }
 
+// WithChess960 sets the Chess960 mode.
+func WithChess960(on bool) func(*Engine) {
+	return func(e *Engine) {
+		e.options.chess960 = on
+	}
+}
+
+// WithNullMove sets the null move pruning.
+func WithNullMove(on bool) func(*Engine) {
+	return func(e *Engine) {
+		e.options.nullMove = on
+	}
+}
+
+// WithNullMoveVerify sets the verification of null move cutoffs.
+func WithNullMoveVerify(on bool) func(*Engine) {
+	return func(e *Engine) {
+		e.options.nullMoveVerify = on
+	}
+}
+
+// WithLateMoveReductions sets the late move reductions and pruning.
+func WithLateMoveReductions(on bool) func(*Engine) {
+	return func(e *Engine) {
+		e.options.lateMoveReductions = on
+	}
+}
+
+// WithExtension enables or disables the search extensions.
+func WithExtension(extension search.Extension, on bool) func(*Engine) {
+	return func(e *Engine) {
+		if on {
+			e.options.extensions |= extension
+		} else {
+			e.options.extensions &^= extension
+		}
+	}
+}
+
+// WithExtensionBudget sets the maximum plies of extension along a line.
+func WithExtensionBudget(plies int) func(*Engine) {
+	return func(e *Engine) {
+		e.options.extensionBudget = plies
+	}
+}
+
+// WithFutilityMargin sets the futility pruning margin in centipawns per ply.
+func WithFutilityMargin(margin int) func(*Engine) {
+	return func(e *Engine) {
+		e.options.margins.Futility = margin
+	}
+}
+
+// WithReverseFutilityMargin sets the reverse futility pruning margin in centipawns per ply.
+func WithReverseFutilityMargin(margin int) func(*Engine) {
+	return func(e *Engine) {
+		e.options.margins.ReverseFutility = margin
+	}
+}
+
+// WithRazoringMargin sets the razoring margin in centipawns per ply.
+func WithRazoringMargin(margin int) func(*Engine) {
+	return func(e *Engine) {
+		e.options.margins.Razoring = margin
+	}
+}
+
 // Debug sets the debug option.
 func (e *Engine) Debug(on bool) {
 	e.debug = on
@@ -215,38 +300,82 @@ func (e *Engine) SetOption(name, value string) error {
 }
 
 // SetPosition sets the position to the provided FEN.
+//
+// Positions that could not occur in a game are rejected.
 func (e *Engine) SetPosition(fen string) error {
-	fn, err := chess.FEN(fen)
+	pos, err := ichess.FromFEN(fen)
 	if err != nil {
 		return err
 	}
-	fn(e.game)
-	e.log("position set to", e.game.Position())
+
+	if err := pos.Validate(); err != nil {
+		return err
+	}
+
+	// the game cannot represent Chess960 castling rights,
+	// only the mirror is set in Chess960 mode
+	if !e.options.chess960 {
+		fn, err := chess.FEN(fen)
+		if err != nil {
+			return err
+		}
+		fn(e.game)
+	}
+
+	e.position = ichess.NewGame(pos)
+	e.log("position set to", e.position.Position().FEN())
 	return nil
 }
 
 // Move plays the moves on the current position.
 func (e *Engine) Move(moves ...string) error {
 	for _, move := range moves {
+		if e.options.chess960 {
+			if err := e.moveChess960(move); err != nil {
+				return err
+			}
+			continue
+		}
+
 		m, err := e.notation.Decode(e.game.Position(), move)
 		if err != nil {
 			return err
 		}
 
+		mirror, err := ichess.MoveFromUCI(e.position.Position(), chess.UCINotation{}.Encode(e.game.Position(), m))
+		if err != nil {
+			return err
+		}
+
 		if err := e.game.Move(m); err != nil {
 			return err
 		}
+
+		if !e.position.MakeMove(mirror) {
+			return errIllegalMove
+		}
 	}
-	e.log("position set to", e.game.Position())
+	e.log("position set to", e.position.Position().FEN())
 	return nil
 }
 
 // ResetPosition resets the position to the starting one.
 func (e *Engine) ResetPosition() {
 	e.game = chess.NewGame()
+	e.position = ichess.NewGame(ichess.StartingPosition())
 	e.log("position set to start")
 }
 
+// NewGame prepares the engine for a new game.
+//
+// Oracles learning from the search forget what was learned in the previous game.
+func (e *Engine) NewGame() {
+	if h, ok := e.options.oracle.(oracle.Heuristic); ok {
+		h.Reset()
+	}
+	e.log("new game")
+}
+
 // Search runs a search on the given input.
 func (e *Engine) Search(ctx context.Context, input uci.Input) (<-chan uci.Output, error) {
 	engineOutput := make(chan uci.Output)
@@ -256,6 +385,10 @@ func (e *Engine) Search(ctx context.Context, input uci.Input) (<-chan uci.Output
 		return engineOutput, errSearch
 	}
 
+	if e.options.chess960 {
+		return e.searchChess960(ctx, input), nil
+	}
+
 	if move := e.options.opening.Move(e.game.Position()); move != nil {
 		e.log("playing move from opening book")
 		go func() {
@@ -276,14 +409,20 @@ func (e *Engine) Search(ctx context.Context, input uci.Input) (<-chan uci.Output
 		e.log("could not parse search moves, defaulting to all possible moves", err)
 	}
 	searchOutput := search.Run(ctx, search.Input{
-		Position:      e.game.Position(),
-		SearchMoves:   searchMoves,
-		Depth:         input.Depth,
-		Search:        e.options.search,
-		Evaluation:    e.options.evaluation,
-		Oracle:        e.options.oracle,
-		Quiescence:    e.options.quiescence,
-		Transposition: e.options.transposition,
+		Position:           e.game.Position(),
+		SearchMoves:        searchMoves,
+		Depth:              input.Depth,
+		Search:             e.options.search,
+		Evaluation:         e.options.evaluation,
+		Oracle:             e.options.oracle,
+		Quiescence:         e.options.quiescence,
+		Transposition:      e.options.transposition,
+		NullMove:           e.options.nullMove,
+		NullMoveVerify:     e.options.nullMoveVerify,
+		LateMoveReductions: e.options.lateMoveReductions,
+		Extensions:         e.options.extensions,
+		ExtensionBudget:    e.options.extensionBudget,
+		Margins:            e.options.margins,
 	})
 
 	go func() {
@@ -292,6 +431,12 @@ func (e *Engine) Search(ctx context.Context, input uci.Input) (<-chan uci.Output
 		defer close(engineOutput)
 
 		for output := range searchOutput {
+			if output.Err != nil {
+				e.log("search failed", output.Err)
+				engineOutput <- uci.Output{Info: fmt.Sprintf("search failed: %v", output.Err)}
+				continue
+			}
+
 			var pv []string
 			for _, move := range output.PV {
 				pv = append(pv, e.notation.Encode(e.game.Position(), move))
@@ -303,6 +448,7 @@ func (e *Engine) Search(ctx context.Context, input uci.Input) (<-chan uci.Output
 				Nodes: output.Nodes,
 				Score: output.Score,
 				Mate:  output.Mate,
+				Bound: uciBound(output.Bound),
 				PV:    pv,
 			}
 		}
@@ -348,6 +494,18 @@ func searchMoves(notation chess.Notation, position *chess.Position, moves []stri
 	return next, nil
 }
 
+// uciBound converts the bound of a search score
+func uciBound(bound search.Bound) uci.Bound {
+	switch bound {
+	case search.LowerBound:
+		return uci.BoundLower
+	case search.UpperBound:
+		return uci.BoundUpper
+	default:
+		return uci.BoundExact
+	}
+}
+
 // searchContext creates a new context from the input
 func searchContext(ctx context.Context, input uci.Input, stop <-chan struct{}) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -29,6 +29,12 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, &transposition.Ristretto{}, e.options.transposition)
 	assert.Equal(t, opening.NewWeightedRandom().String(), e.options.opening.String())
 	assert.Equal(t, 32, e.options.hash)
+	assert.True(t, e.options.nullMove)
+	assert.True(t, e.options.nullMoveVerify)
+	assert.False(t, e.options.lateMoveReductions)
+	assert.Equal(t, search.AllExtensions&^search.RecaptureExtension, e.options.extensions)
+	assert.Equal(t, 4, e.options.extensionBudget)
+	assert.Equal(t, search.Margins{Futility: 100, ReverseFutility: 120, Razoring: 300}, e.options.margins)
 }
 
 func TestWithName(t *testing.T) {
@@ -51,11 +57,52 @@ func TestWithEvaluation(t *testing.T) {
 	assert.Equal(t, evaluation.Simplified{}, e.options.evaluation)
 }
 
+func TestWithOracle(t *testing.T) {
+	kh := &oracle.KillerHistory{}
+	e1, e2 := New(WithOracle(kh)), New(WithOracle(kh))
+	assert.Equal(t, kh, e1.options.oracle)
+	assert.NotSame(t, kh, e1.options.oracle)
+	assert.NotSame(t, e1.options.oracle, e2.options.oracle)
+}
+
 func TestWithQuiescence(t *testing.T) {
 	e := New(WithQuiescence(quiescence.AlphaBeta{}))
 	assert.Equal(t, quiescence.AlphaBeta{}, e.options.quiescence)
 }
 
+func TestWithNullMove(t *testing.T) {
+	e := New(WithNullMove(false))
+	assert.False(t, e.options.nullMove)
+}
+
+func TestWithNullMoveVerify(t *testing.T) {
+	e := New(WithNullMoveVerify(false))
+	assert.False(t, e.options.nullMoveVerify)
+}
+
+func TestWithLateMoveReductions(t *testing.T) {
+	e := New(WithLateMoveReductions(true))
+	assert.True(t, e.options.lateMoveReductions)
+}
+
+func TestWithExtension(t *testing.T) {
+	e := New(WithExtension(search.CheckExtension|search.SingularExtension, false))
+	assert.Equal(t, search.PassedPawnExtension, e.options.extensions)
+
+	WithExtension(search.RecaptureExtension, true)(e)
+	assert.Equal(t, search.RecaptureExtension|search.PassedPawnExtension, e.options.extensions)
+}
+
+func TestWithExtensionBudget(t *testing.T) {
+	e := New(WithExtensionBudget(4))
+	assert.Equal(t, 4, e.options.extensionBudget)
+}
+
+func TestWithMargins(t *testing.T) {
+	e := New(WithFutilityMargin(150), WithReverseFutilityMargin(0), WithRazoringMargin(400))
+	assert.Equal(t, search.Margins{Futility: 150, ReverseFutility: 0, Razoring: 400}, e.options.margins)
+}
+
 func TestInfo(t *testing.T) {
 	e := New(WithName("NAME"), WithAuthor("AUTHOR"))
 	name, author := e.Info()
@@ -112,7 +159,7 @@ func TestOptions(t *testing.T) {
 			Type:    uci.OptionEnum,
 			Name:    "SearchStrategy",
 			Default: "AlphaBeta",
-			Vars:    []string{"Random", "Capture", "Negamax", "AlphaBeta"},
+			Vars:    []string{"Random", "Capture", "Negamax", "AlphaBeta", "AlphaBetaV2", "PVS"},
 		},
 		{
 			Type:    uci.OptionEnum,
@@ -124,7 +171,7 @@ func TestOptions(t *testing.T) {
 			Type:    uci.OptionEnum,
 			Name:    "OracleStrategy",
 			Default: "Order",
-			Vars:    []string{"None", "Order"},
+			Vars:    []string{"None", "Order", "KillerHistory", "MVVLVA", "MVVLVASEE"},
 		},
 		{
 			Type:    uci.OptionEnum,
@@ -151,6 +198,74 @@ func TestOptions(t *testing.T) {
 			Min:     "1",
 			Max:     "1024",
 		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "UCI_Chess960",
+			Default: "false",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "NullMove",
+			Default: "true",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "NullMoveVerify",
+			Default: "true",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "LateMoveReductions",
+			Default: "false",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "CheckExtension",
+			Default: "true",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "RecaptureExtension",
+			Default: "false",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "PassedPawnExtension",
+			Default: "true",
+		},
+		{
+			Type:    uci.OptionBoolean,
+			Name:    "SingularExtension",
+			Default: "true",
+		},
+		{
+			Type:    uci.OptionInteger,
+			Name:    "ExtensionBudget",
+			Default: "4",
+			Min:     "0",
+			Max:     "32",
+		},
+		{
+			Type:    uci.OptionInteger,
+			Name:    "FutilityMargin",
+			Default: "100",
+			Min:     "0",
+			Max:     "1000",
+		},
+		{
+			Type:    uci.OptionInteger,
+			Name:    "ReverseFutilityMargin",
+			Default: "120",
+			Min:     "0",
+			Max:     "1000",
+		},
+		{
+			Type:    uci.OptionInteger,
+			Name:    "RazoringMargin",
+			Default: "300",
+			Min:     "0",
+			Max:     "1000",
+		},
 	}, options)
 }
 
@@ -221,6 +336,14 @@ func TestSetPositionInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSetPositionImpossible(t *testing.T) {
+	fen := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e6 0 1"
+	e := New()
+	err := e.SetPosition(fen)
+	assert.EqualError(t, err, "invalid position: en passant square e6 on the wrong rank")
+	assert.Equal(t, chess.StartingPosition().String(), e.game.Position().String())
+}
+
 func TestMoveValid(t *testing.T) {
 	fen := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
 	e := New()
@@ -242,12 +365,164 @@ func TestMoveInvalidMove(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMoveChess960(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		moves []string
+		want  string
+	}{
+		{
+			name:  "standard move",
+			fen:   "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9",
+			moves: []string{"e2e4"},
+			want:  "bqnb1rkr/pp3ppp/3ppn2/2p5/4PP2/P2P4/NPP3PP/BQ1BNRKR b KQkq e3 0 9",
+		},
+		{
+			name:  "king takes rook",
+			fen:   "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BR1KR w HEhf - 2 9",
+			moves: []string{"g1h1"},
+			want:  "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BRRK1 b kq - 3 9",
+		},
+		{
+			name:  "standard castle",
+			fen:   "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			moves: []string{"e1g1", "e8a8"},
+			want:  "2kr3r/8/8/8/8/8/8/R4RK1 w - - 2 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New(WithChess960(true))
+			if assert.NoError(t, e.SetPosition(tt.fen)) {
+				assert.NoError(t, e.Move(tt.moves...))
+				assert.Equal(t, tt.want, e.position.Position().FEN())
+			}
+		})
+	}
+}
+
+func TestMoveChess960Illegal(t *testing.T) {
+	e := New(WithChess960(true))
+	err := e.Move("e1h1")
+	assert.Equal(t, errIllegalMove, err)
+}
+
+func TestMoveChess960History(t *testing.T) {
+	e := New(WithChess960(true))
+	if assert.NoError(t, e.SetPosition("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")) {
+		assert.NoError(t, e.Move("e1h1"))
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, e.Move("a8b8", "a1b1", "b8a8", "b1a1"))
+		}
+		assert.Len(t, e.position.Moves(), 13)
+		assert.Equal(t, 2, e.position.Repetitions())
+	}
+}
+
+func TestSearchChess960(t *testing.T) {
+	tests := []struct {
+		name   string
+		fen    string
+		depth  int
+		search []string
+		mate   int
+		pv     []string
+	}{
+		{
+			name:  "mate in 1",
+			fen:   "8/8/8/5K1k/8/8/8/5R2 w - - 0 1",
+			depth: 1,
+			mate:  1,
+			pv:    []string{"f1h1"},
+		},
+		{
+			name:   "castle",
+			fen:    "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BR1KR w HEhf - 2 9",
+			depth:  1,
+			search: []string{"g1h1"},
+			pv:     []string{"g1h1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New(WithChess960(true))
+			e.initialized = true
+			if !assert.NoError(t, e.SetPosition(tt.fen)) {
+				return
+			}
+
+			output, err := e.Search(context.Background(), uci.Input{Depth: tt.depth, SearchMoves: tt.search})
+			assert.NoError(t, err)
+
+			var outputs []uci.Output
+			for o := range output {
+				outputs = append(outputs, o)
+			}
+			if !assert.Len(t, outputs, tt.depth+1) {
+				return
+			}
+			assert.Equal(t, uci.Output{Info: chess960SearchInfo}, outputs[0])
+
+			last := outputs[len(outputs)-1]
+			assert.Equal(t, tt.depth, last.Depth)
+			assert.Equal(t, tt.mate, last.Mate)
+			assert.Equal(t, tt.pv, last.PV)
+		})
+	}
+}
+
+func TestSearchChess960_Repetition(t *testing.T) {
+	e := New(WithChess960(true))
+	e.initialized = true
+	if !assert.NoError(t, e.SetPosition("7k/8/8/8/8/8/8/K2Q4 b - - 0 1")) {
+		return
+	}
+	assert.NoError(t, e.Move("h8g8", "a1b1", "g8h8", "b1a1", "h8g8"))
+
+	output, err := e.Search(context.Background(), uci.Input{Depth: 2, SearchMoves: []string{"a1b1"}})
+	assert.NoError(t, err)
+
+	var last uci.Output
+	for o := range output {
+		last = o
+	}
+	assert.Equal(t, 0, last.Score)
+	assert.Equal(t, []string{"a1b1"}, last.PV)
+}
+
+func TestMoveMirror(t *testing.T) {
+	e := New()
+	assert.NoError(t, e.Move("e2e4"))
+	assert.Equal(t, e.game.Position().String(), e.position.Position().FEN())
+
+	WithChess960(true)(e)
+	assert.NoError(t, e.Move("e7e5"))
+	assert.Equal(t, "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", e.position.Position().FEN())
+}
+
 func TestResetPosition(t *testing.T) {
 	e := New()
 	e.ResetPosition()
 	assert.Equal(t, chess.StartingPosition().String(), e.game.Position().String())
 }
 
+func TestNewGame(t *testing.T) {
+	kh := &oracle.KillerHistory{}
+	pos := chess.StartingPosition()
+	move := pos.ValidMoves()[0]
+	kh.Cutoff(oracle.Node{Position: pos, Ply: 1, Depth: 1}, move)
+
+	e := New(WithOracle(kh))
+	h := e.options.oracle.(oracle.Heuristic)
+	assert.True(t, h.Killer(1, move))
+
+	e.NewGame()
+	assert.False(t, h.Killer(1, move))
+}
+
 func TestSearch_Initialized(t *testing.T) {
 	s := new(mockSearch)
 	s.On("Search").Unset()
@@ -256,6 +531,31 @@ func TestSearch_Initialized(t *testing.T) {
 	assert.Equal(t, errSearch, err)
 }
 
+func TestSearch_Error(t *testing.T) {
+	e := New(WithSearch(errorSearch{}), WithOpening(opening.NewNone()))
+	e.initialized = true
+
+	output, err := e.Search(context.Background(), uci.Input{Depth: 1})
+	assert.NoError(t, err)
+
+	var outputs []uci.Output
+	for o := range output {
+		outputs = append(outputs, o)
+	}
+	assert.Equal(t, []uci.Output{{Info: "search failed: test search error"}}, outputs)
+}
+
+// errorSearch implements search.Interface with a search that always fails.
+type errorSearch struct{}
+
+func (errorSearch) String() string {
+	return "Error"
+}
+
+func (errorSearch) Search(_ context.Context, _ search.Input, output chan<- *search.Output) {
+	output <- &search.Output{Err: errors.New("test search error")}
+}
+
 // mockSearch is a mock that implements search.Interface
 type mockSearch struct {
 	mock.Mock
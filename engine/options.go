@@ -26,6 +26,18 @@ var (
 		transpositionStrategy,
 		openingStrategy,
 		hashOption,
+		chess960Option,
+		nullMoveOption,
+		nullMoveVerifyOption,
+		lateMoveReductionsOption,
+		checkExtensionOption,
+		recaptureExtensionOption,
+		passedPawnExtensionOption,
+		singularExtensionOption,
+		extensionBudgetOption,
+		futilityMarginOption,
+		reverseFutilityMarginOption,
+		razoringMarginOption,
 	}
 
 	searchStrategy = optionStrategy[search.Interface]{
@@ -36,6 +48,8 @@ var (
 			search.Capture{},
 			search.Negamax{},
 			search.AlphaBeta{},
+			search.AlphaBetaV2{},
+			search.PVS{},
 		},
 		fn: WithSearch,
 	}
@@ -57,6 +71,9 @@ var (
 		vars: []oracle.Interface{
 			oracle.None{},
 			oracle.Order{},
+			&oracle.KillerHistory{},
+			oracle.MVVLVA{},
+			oracle.MVVLVA{SEE: true},
 		},
 		fn: WithOracle,
 	}
@@ -100,8 +117,95 @@ var (
 		max:  1024,
 		fn:   WithHash,
 	}
+
+	chess960Option = optionBoolean{
+		name: "UCI_Chess960",
+		def:  false,
+		fn:   WithChess960,
+	}
+
+	nullMoveOption = optionBoolean{
+		name: "NullMove",
+		def:  true,
+		fn:   WithNullMove,
+	}
+
+	nullMoveVerifyOption = optionBoolean{
+		name: "NullMoveVerify",
+		def:  true,
+		fn:   WithNullMoveVerify,
+	}
+
+	lateMoveReductionsOption = optionBoolean{
+		name: "LateMoveReductions",
+		def:  false,
+		fn:   WithLateMoveReductions,
+	}
+
+	checkExtensionOption = optionBoolean{
+		name: "CheckExtension",
+		def:  true,
+		fn:   extensionFunc(search.CheckExtension),
+	}
+
+	recaptureExtensionOption = optionBoolean{
+		name: "RecaptureExtension",
+		def:  false,
+		fn:   extensionFunc(search.RecaptureExtension),
+	}
+
+	passedPawnExtensionOption = optionBoolean{
+		name: "PassedPawnExtension",
+		def:  true,
+		fn:   extensionFunc(search.PassedPawnExtension),
+	}
+
+	singularExtensionOption = optionBoolean{
+		name: "SingularExtension",
+		def:  true,
+		fn:   extensionFunc(search.SingularExtension),
+	}
+
+	extensionBudgetOption = optionInteger{
+		name: "ExtensionBudget",
+		def:  4,
+		min:  0,
+		max:  32,
+		fn:   WithExtensionBudget,
+	}
+
+	futilityMarginOption = optionInteger{
+		name: "FutilityMargin",
+		def:  100,
+		min:  0,
+		max:  1000,
+		fn:   WithFutilityMargin,
+	}
+
+	reverseFutilityMarginOption = optionInteger{
+		name: "ReverseFutilityMargin",
+		def:  120,
+		min:  0,
+		max:  1000,
+		fn:   WithReverseFutilityMargin,
+	}
+
+	razoringMarginOption = optionInteger{
+		name: "RazoringMargin",
+		def:  300,
+		min:  0,
+		max:  1000,
+		fn:   WithRazoringMargin,
+	}
 )
 
+// extensionFunc returns the option function of a search extension.
+func extensionFunc(extension search.Extension) func(bool) func(*Engine) {
+	return func(on bool) func(*Engine) {
+		return WithExtension(extension, on)
+	}
+}
+
 // option is the interface implemented by each option type.
 type option interface {
 	fmt.Stringer
@@ -110,6 +214,42 @@ type option interface {
 	optionFunc(value string) (func(*Engine), error)
 }
 
+// optionBoolean represents a boolean option.
+type optionBoolean struct {
+	name string
+	def  bool
+	fn   func(bool) func(*Engine)
+}
+
+// String implements the option interface.
+func (o optionBoolean) String() string {
+	return o.name
+}
+
+// uci implements the option interface.
+func (o optionBoolean) uci() uci.Option {
+	return uci.Option{
+		Type:    uci.OptionBoolean,
+		Name:    o.name,
+		Default: strconv.FormatBool(o.def),
+	}
+}
+
+// defaultFunc implements the option interface.
+func (o optionBoolean) defaultFunc() func(*Engine) {
+	return o.fn(o.def)
+}
+
+// optionFunc implements the option interface.
+func (o optionBoolean) optionFunc(value string) (func(*Engine), error) {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return func(e *Engine) {}, errOptionValue
+	}
+
+	return o.fn(v), nil
+}
+
 // optionInteger represents an integer option.
 type optionInteger struct {
 	name          string
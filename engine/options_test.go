@@ -24,6 +24,8 @@ func TestOptionStrategyUCI(t *testing.T) {
 			search.Capture{}.String(),
 			search.Negamax{}.String(),
 			search.AlphaBeta{}.String(),
+			search.AlphaBetaV2{}.String(),
+			search.PVS{}.String(),
 		},
 	}, searchStrategy.uci())
 }
@@ -128,3 +130,55 @@ func TestOptionIntegerOptionFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionBooleanString(t *testing.T) {
+	assert.Equal(t, chess960Option.name, chess960Option.String())
+}
+
+func TestOptionBooleanUCI(t *testing.T) {
+	assert.Equal(t, uci.Option{
+		Type:    uci.OptionBoolean,
+		Name:    chess960Option.name,
+		Default: "false",
+	}, chess960Option.uci())
+}
+
+// optionBoolean.defaultFunc tested in New
+
+func TestOptionBooleanOptionFunc(t *testing.T) {
+	type want struct {
+		value bool
+		err   string
+	}
+
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{
+			name: "value cannot be parsed as boolean",
+			args: "foobar",
+			want: want{false, errOptionValue.Error()},
+		},
+		{
+			name: "value is valid",
+			args: "true",
+			want: want{true, ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := chess960Option.optionFunc(tt.args)
+			if err != nil {
+				assert.Equal(t, tt.want.err, err.Error())
+				return
+			}
+
+			e := New()
+			fn(e)
+			assert.Equal(t, tt.want.value, e.options.chess960)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package oracle
+
+import (
+	"sort"
+
+	"github.com/notnil/chess"
+)
+
+const (
+	// killerPlies is the number of plies for which killer moves are kept.
+	killerPlies = 128
+	// historyMax is the history score above which the table is aged.
+	historyMax = 1 << 20
+)
+
+// Scores of the move categories, from best to worst. Quiet moves
+// that are neither killer nor counter moves are scored by their history.
+const (
+	captureScore = 1 << 30
+	killerScore  = 1 << 29
+	counterScore = 1 << 28
+)
+
+// KillerHistory implements move ordering with the killer move, history
+// and counter move heuristics, learned from the beta cutoffs of the search.
+//
+// Captures and queen promotions are sorted first as with MVVLVA. Then come
+// the two killer moves of the ply, the quiet moves that most recently caused
+// a cutoff among siblings. Then the counter move of the previous move.
+// The other quiet moves are sorted by their history, a score indexed by
+// side, origin and destination squares that grows with each cutoff.
+//
+// The tables are kept between searches and cleared by Reset.
+type KillerHistory struct {
+	killers [killerPlies][2]moveKey
+	counter [64][64]moveKey
+	history [2][64][64]int
+}
+
+// moveKey identifies a move independently of its position.
+type moveKey struct {
+	s1, s2 chess.Square
+	promo  chess.PieceType
+}
+
+// String implements the Interface interface.
+func (*KillerHistory) String() string {
+	return "KillerHistory"
+}
+
+// Order implements the Interface interface.
+//
+// Without the context of a node, moves are sorted as with Order.
+func (*KillerHistory) Order(moves []*chess.Move) {
+	Order{}.Order(moves)
+}
+
+// OrderNode implements the NodeOrderer interface.
+func (kh *KillerHistory) OrderNode(node Node, moves []*chess.Move) {
+	scores := make([]int, len(moves))
+	for i, move := range moves {
+		scores[i] = kh.score(node, move)
+	}
+
+	sort.Sort(byScore{moves, scores})
+}
+
+// Cutoff implements the Heuristic interface.
+//
+// Only quiet moves are recorded, as captures are already sorted first.
+func (kh *KillerHistory) Cutoff(node Node, move *chess.Move) {
+	if !isQuiet(move) {
+		return
+	}
+
+	key := newMoveKey(move)
+	if node.Ply < killerPlies && kh.killers[node.Ply][0] != key {
+		kh.killers[node.Ply][1] = kh.killers[node.Ply][0]
+		kh.killers[node.Ply][0] = key
+	}
+
+	if node.Previous != nil {
+		kh.counter[node.Previous.S1()][node.Previous.S2()] = key
+	}
+
+	history := &kh.history[node.Position.Turn()-1]
+	history[move.S1()][move.S2()] += node.Depth * node.Depth
+	if history[move.S1()][move.S2()] > historyMax {
+		kh.age()
+	}
+}
+
+// Killer implements the Heuristic interface.
+func (kh *KillerHistory) Killer(ply int, move *chess.Move) bool {
+	if ply >= killerPlies {
+		return false
+	}
+
+	key := newMoveKey(move)
+	return kh.killers[ply][0] == key || kh.killers[ply][1] == key
+}
+
+// Reset implements the Heuristic interface.
+func (kh *KillerHistory) Reset() {
+	*kh = KillerHistory{}
+}
+
+// score returns the ordering score of the move in the node.
+func (kh *KillerHistory) score(node Node, move *chess.Move) int {
+	switch {
+	case isCapture(move):
+		return captureScore + mvvLva(node.Position.Board(), move)
+	case !isQuiet(move):
+		return captureScore + rank(move)
+	}
+
+	key := newMoveKey(move)
+	if node.Ply < killerPlies {
+		switch key {
+		case kh.killers[node.Ply][0]:
+			return killerScore + 1
+		case kh.killers[node.Ply][1]:
+			return killerScore
+		}
+	}
+
+	if node.Previous != nil && kh.counter[node.Previous.S1()][node.Previous.S2()] == key {
+		return counterScore
+	}
+
+	return kh.history[node.Position.Turn()-1][move.S1()][move.S2()]
+}
+
+// age halves the history scores so that recent cutoffs weigh more.
+func (kh *KillerHistory) age() {
+	for c := range kh.history {
+		for s1 := range kh.history[c] {
+			for s2 := range kh.history[c][s1] {
+				kh.history[c][s1][s2] /= 2
+			}
+		}
+	}
+}
+
+// newMoveKey returns the key of the move.
+func newMoveKey(move *chess.Move) moveKey {
+	return moveKey{move.S1(), move.S2(), move.Promo()}
+}
+
+// isQuiet reports whether the move is neither a capture nor a queen promotion.
+func isQuiet(move *chess.Move) bool {
+	return !isCapture(move) && move.Promo() != chess.Queen
+}
+
+// byScore sorts moves by descending score.
+type byScore struct {
+	moves  []*chess.Move
+	scores []int
+}
+
+func (s byScore) Len() int { return len(s.moves) }
+
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+
+func (s byScore) Swap(i, j int) {
+	s.moves[i], s.moves[j] = s.moves[j], s.moves[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/notnil/chess"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKillerHistoryOrderNode(t *testing.T) {
+	pos := position("4k3/8/8/3p4/4P3/8/8/R3K3 w - - 0 1")
+	previous := position("4k3/8/8/8/3pP3/8/8/R3K3 b - - 0 1").ValidMoves()[0]
+
+	kh := &KillerHistory{}
+	node := Node{Position: pos, Previous: previous, Ply: 2, Depth: 3}
+	kh.Cutoff(Node{Position: pos, Ply: 2, Depth: 1}, move(pos, "a1a7"))
+	kh.Cutoff(Node{Position: pos, Ply: 2, Depth: 1}, move(pos, "a1a6"))
+	kh.Cutoff(Node{Position: pos, Previous: previous, Ply: 3, Depth: 2}, move(pos, "e1d1"))
+	kh.Cutoff(Node{Position: pos, Ply: 3, Depth: 8}, move(pos, "a1b1"))
+	kh.Cutoff(Node{Position: pos, Ply: 3, Depth: 4}, move(pos, "a1c1"))
+	kh.Cutoff(Node{Position: pos, Ply: 2, Depth: 1}, move(pos, "e4d5"))
+
+	moves := pos.ValidMoves()
+	kh.OrderNode(node, moves)
+
+	var got []string
+	for _, m := range moves[:6] {
+		got = append(got, m.String())
+	}
+	assert.Equal(t, []string{"e4d5", "a1a6", "a1a7", "e1d1", "a1b1", "a1c1"}, got)
+}
+
+func TestKillerHistoryCutoff(t *testing.T) {
+	pos := position("4k3/8/8/3p4/4P3/8/8/R3K3 w - - 0 1")
+	node := Node{Position: pos, Ply: 1, Depth: 2}
+
+	kh := &KillerHistory{}
+	kh.Cutoff(node, move(pos, "a1a7"))
+	kh.Cutoff(node, move(pos, "a1a7"))
+	kh.Cutoff(node, move(pos, "a1a6"))
+	kh.Cutoff(node, move(pos, "e4d5"))
+
+	assert.True(t, kh.Killer(1, move(pos, "a1a7")))
+	assert.True(t, kh.Killer(1, move(pos, "a1a6")))
+	assert.False(t, kh.Killer(1, move(pos, "e4d5")))
+	assert.False(t, kh.Killer(2, move(pos, "a1a7")))
+	assert.False(t, kh.Killer(killerPlies, move(pos, "a1a7")))
+	assert.Equal(t, 8, kh.history[0][chess.A1][chess.A7])
+	assert.Equal(t, 0, kh.history[1][chess.A1][chess.A7])
+
+	kh.Reset()
+	assert.False(t, kh.Killer(1, move(pos, "a1a7")))
+	assert.Equal(t, 0, kh.history[0][chess.A1][chess.A7])
+}
+
+func TestKillerHistoryAge(t *testing.T) {
+	pos := position("4k3/8/8/3p4/4P3/8/8/R3K3 w - - 0 1")
+
+	kh := &KillerHistory{}
+	kh.history[0][chess.A1][chess.B1] = 10
+	kh.history[0][chess.A1][chess.A7] = historyMax
+	kh.Cutoff(Node{Position: pos, Depth: 1}, move(pos, "a1a7"))
+
+	assert.Equal(t, (historyMax+1)/2, kh.history[0][chess.A1][chess.A7])
+	assert.Equal(t, 5, kh.history[0][chess.A1][chess.B1])
+}
+
+func move(pos *chess.Position, uci string) *chess.Move {
+	for _, m := range pos.ValidMoves() {
+		if m.String() == uci {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package oracle
+
+import (
+	"sort"
+
+	"github.com/notnil/chess"
+
+	ichess "github.com/leonhfr/honeybadger/chess"
+)
+
+// MVVLVA implements move ordering of captures by most valuable victim,
+// least valuable attacker: captures of the most valuable pieces are sorted
+// first, and among them the captures by the least valuable pieces.
+// The other moves are sorted after the captures as with Order.
+//
+// When SEE is set, captures that lose material according to the static
+// exchange evaluation are sorted after the other moves.
+type MVVLVA struct {
+	SEE bool // Sort losing captures last.
+}
+
+// String implements the Interface interface.
+func (o MVVLVA) String() string {
+	if o.SEE {
+		return "MVVLVASEE"
+	}
+	return "MVVLVA"
+}
+
+// Order implements the Interface interface.
+//
+// Without the position of the node, moves are sorted as with Order.
+func (MVVLVA) Order(moves []*chess.Move) {
+	Order{}.Order(moves)
+}
+
+// OrderNode implements the NodeOrderer interface.
+func (o MVVLVA) OrderNode(node Node, moves []*chess.Move) {
+	board := node.Position.Board()
+
+	var pos *ichess.Position
+	if o.SEE {
+		pos = seePosition(node.Position)
+	}
+
+	scores := make([]int, len(moves))
+	for i, move := range moves {
+		scores[i] = o.score(board, pos, move)
+	}
+
+	sort.Stable(byScore{moves, scores})
+}
+
+// score returns the ordering score of the move.
+//
+// Losing captures are sorted last when the position
+// for the static exchange evaluation is provided.
+func (MVVLVA) score(board *chess.Board, pos *ichess.Position, move *chess.Move) int {
+	if !isCapture(move) {
+		return rank(move)
+	}
+
+	if pos != nil && see(pos, move) < 0 {
+		return -captureScore + mvvLva(board, move)
+	}
+
+	return captureScore + mvvLva(board, move)
+}
+
+// mvvLva returns the score of a capture, higher for more valuable victims
+// and for less valuable attackers among captures of the same victim.
+func mvvLva(board *chess.Board, move *chess.Move) int {
+	victim := chess.Pawn
+	if !move.HasTag(chess.EnPassant) {
+		victim = board.Piece(move.S2()).Type()
+	}
+
+	attacker := board.Piece(move.S1()).Type()
+	return 8*mvvLvaPoints[victim] - mvvLvaPoints[attacker] + promoPoints[move.Promo()]
+}
+
+// mvvLvaPoints ranks the piece types by value, indexed by chess.PieceType.
+var mvvLvaPoints = [...]int{
+	chess.NoPieceType: 0,
+	chess.King:        6,
+	chess.Queen:       5,
+	chess.Rook:        4,
+	chess.Bishop:      3,
+	chess.Knight:      2,
+	chess.Pawn:        1,
+}
+
+// isCapture reports whether the move is a capture.
+func isCapture(move *chess.Move) bool {
+	return move.HasTag(chess.Capture) || move.HasTag(chess.EnPassant)
+}
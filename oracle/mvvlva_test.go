@@ -0,0 +1,90 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMVVLVAOrderNode(t *testing.T) {
+	tests := []struct {
+		name   string
+		oracle MVVLVA
+		args   string
+		want   []string
+	}{
+		{
+			"mvv-lva",
+			MVVLVA{},
+			"4k3/8/2n5/3P4/4r3/8/3NQ3/4K3 w - - 0 1",
+			[]string{"d2e4", "e2e4", "d5c6"},
+		},
+		{
+			"en passant",
+			MVVLVA{},
+			"4k3/8/8/3Pp3/8/8/8/4K3 w - e6 0 1",
+			[]string{"d5e6"},
+		},
+		{
+			"losing capture",
+			MVVLVA{},
+			"4k3/4p3/3p4/8/8/3Q4/8/4K3 w - - 0 1",
+			[]string{"d3d6"},
+		},
+		{
+			"losing capture with see",
+			MVVLVA{SEE: true},
+			"4k3/4p3/3p4/8/8/3Q4/8/4K3 w - - 0 1",
+			[]string{
+				"d3b5", "d3g6", "e1d1", "e1f1", "e1d2", "e1e2", "e1f2", "d3b1", "d3d1",
+				"d3f1", "d3c2", "d3d2", "d3e2", "d3a3", "d3b3", "d3c3", "d3e3", "d3f3",
+				"d3g3", "d3h3", "d3c4", "d3d4", "d3e4", "d3d5", "d3f5", "d3a6", "d3h7",
+				"d3d6",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := position(tt.args)
+			moves := pos.ValidMoves()
+
+			tt.oracle.OrderNode(Node{Position: pos}, moves)
+			var got []string
+			for _, move := range moves[:len(tt.want)] {
+				got = append(got, move.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMVVLVAString(t *testing.T) {
+	assert.Equal(t, "MVVLVA", MVVLVA{}.String())
+	assert.Equal(t, "MVVLVASEE", MVVLVA{SEE: true}.String())
+}
+
+func TestSEE(t *testing.T) {
+	tests := []struct {
+		fen  string
+		move string
+		want int
+	}{
+		{"4k3/8/8/3p4/8/8/8/3RK3 w - - 0 1", "d1d5", 100},
+		{"4k3/4p3/3p4/8/8/3Q4/8/4K3 w - - 0 1", "d3d6", -800},
+		{"4k3/4p3/3p4/8/8/3R4/3R4/4K3 w - - 0 1", "d3d6", -300},
+		{"4k3/2p5/3n4/8/8/3R4/3R4/4K3 w - - 0 1", "d3d6", -100},
+		{"3rk3/8/3p4/8/8/3R4/3R4/3RK3 w - - 0 1", "d3d6", 100},
+		{"4k3/8/8/3Pp3/8/8/8/4K3 w - e6 0 1", "d5e6", 100},
+		{"4k3/3p4/2p5/1B6/8/8/8/4K3 w - - 0 1", "b5c6", -200},
+		{"4k3/8/2p5/1B6/Q7/8/8/4K3 w - - 0 1", "b5c6", 100},
+		{"1r2k3/P7/8/8/8/8/8/4K3 w - - 0 1", "a7b8q", 1300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen, func(t *testing.T) {
+			pos := position(tt.fen)
+			assert.Equal(t, tt.want, see(seePosition(pos), move(pos, tt.move)))
+		})
+	}
+}
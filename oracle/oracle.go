@@ -15,3 +15,27 @@ type Interface interface {
 	// earlier and more frequent alpha/beta cut offs are sorter first.
 	Order(moves []*chess.Move)
 }
+
+// NodeOrderer is the interface implemented by oracles
+// that order moves in the context of their node.
+type NodeOrderer interface {
+	Interface
+	OrderNode(node Node, moves []*chess.Move) // OrderNode sorts the moves of the node.
+}
+
+// Heuristic is the interface implemented by oracles that learn
+// from the beta cutoffs of the search to order the moves of a node.
+type Heuristic interface {
+	NodeOrderer
+	Cutoff(node Node, move *chess.Move)    // Cutoff records a move that caused a beta cutoff in the node.
+	Killer(ply int, move *chess.Move) bool // Killer reports whether the move is a killer move at the ply.
+	Reset()                                // Reset clears what was learned, for example before a new game.
+}
+
+// Node holds the context of a node of the search.
+type Node struct {
+	Position *chess.Position // Position of the node.
+	Previous *chess.Move     // Move that led to the position, nil at the root or after a null move.
+	Ply      int             // Distance to the root in plies.
+	Depth    int             // Remaining depth in plies.
+}
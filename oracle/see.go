@@ -0,0 +1,33 @@
+package oracle
+
+import (
+	"github.com/notnil/chess"
+
+	ichess "github.com/leonhfr/honeybadger/chess"
+)
+
+// seePosition returns the position of the node as a bitboard position of
+// the chess package, which implements the static exchange evaluation,
+// or nil if it cannot be converted.
+//
+// It is converted once per node, before the captures are scored.
+func seePosition(position *chess.Position) *ichess.Position {
+	pos, err := ichess.FromFEN(position.String())
+	if err != nil {
+		return nil
+	}
+	return pos
+}
+
+// see returns the static exchange evaluation of a capture: the material
+// balance after all captures on the destination square, each side
+// capturing with its least valuable piece and stopping when it is better to.
+//
+// Returns 0 if the move cannot be converted.
+func see(pos *ichess.Position, move *chess.Move) int {
+	m, err := ichess.MoveFromUCI(pos, move.String())
+	if err != nil {
+		return 0
+	}
+	return pos.SEE(m)
+}
@@ -0,0 +1,138 @@
+package pgn
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/leonhfr/honeybadger/chess"
+)
+
+// maxLineLength is the maximum length of a movetext line.
+const maxLineLength = 80
+
+// Encode writes the game in PGN export format.
+//
+// The seven tag roster is written first, followed by the other tags in
+// their order. A blank line separates the game from the next one.
+func Encode(w io.Writer, g *Game) error {
+	_, err := io.WriteString(w, g.String())
+	return err
+}
+
+// String implements the Stringer interface.
+// Returns the game in PGN export format.
+func (g *Game) String() string {
+	var sb strings.Builder
+
+	for _, tag := range g.exportTags() {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(tag.Value)
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", tag.Name, value)
+	}
+	sb.WriteByte('\n')
+
+	var tokens []string
+	if g.Comment != "" {
+		tokens = append(tokens, "{"+g.Comment+"}")
+	}
+	start := g.StartingPosition()
+	tokens = appendLine(tokens, start, g.Moves, int(start.FullMoves()))
+	tokens = append(tokens, g.result())
+
+	sb.WriteString(wrap(tokens))
+	sb.WriteString("\n\n")
+	return sb.String()
+}
+
+// exportTags returns the tags in export order.
+func (g *Game) exportTags() []Tag {
+	tags := make([]Tag, 0, len(g.Tags))
+	for _, name := range sevenTagRoster {
+		value, ok := g.Tag(name)
+		switch {
+		case name == "Result":
+			value = g.result()
+		case !ok:
+			value = unknownTagValue(name)
+		}
+		tags = append(tags, Tag{Name: name, Value: value})
+	}
+
+	for _, tag := range g.Tags {
+		if !isSevenTagRoster(tag.Name) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (g *Game) result() string {
+	if g.Result == "" {
+		return NoResult
+	}
+	return g.Result
+}
+
+// appendLine appends the movetext tokens of a line played from pos.
+// The position is modified.
+func appendLine(tokens []string, pos *chess.Position, moves []Move, fullMoves int) []string {
+	for i, m := range moves {
+		white := pos.Turn() == chess.White
+		switch {
+		case white:
+			tokens = append(tokens, fmt.Sprintf("%d.", fullMoves))
+		case i == 0 || moves[i-1].Comment != "" || len(moves[i-1].Variations) > 0:
+			tokens = append(tokens, fmt.Sprintf("%d...", fullMoves))
+		}
+
+		tokens = append(tokens, m.Move.SAN(pos))
+		for _, nag := range m.NAGs {
+			tokens = append(tokens, fmt.Sprintf("$%d", nag))
+		}
+		if m.Comment != "" {
+			tokens = append(tokens, "{"+m.Comment+"}")
+		}
+
+		for _, variation := range m.Variations {
+			tokens = append(tokens, "(")
+			tokens = appendLine(tokens, pos.Copy(), variation, fullMoves)
+			tokens = append(tokens, ")")
+		}
+
+		pos.MakeMove(m.Move)
+		if !white {
+			fullMoves++
+		}
+	}
+	return tokens
+}
+
+// wrap joins the tokens in lines no longer than maxLineLength.
+func wrap(tokens []string) string {
+	var sb strings.Builder
+	var length int
+	for i, tok := range tokens {
+		// no space after an opening or before a closing parenthesis
+		space := i > 0 && tokens[i-1] != "(" && tok != ")"
+		switch {
+		case space && length+1+len(tok) > maxLineLength:
+			sb.WriteByte('\n')
+			length = 0
+		case space:
+			sb.WriteByte(' ')
+			length++
+		}
+		sb.WriteString(tok)
+		length += len(tok)
+	}
+	return sb.String()
+}
+
+func isSevenTagRoster(name string) bool {
+	for _, n := range sevenTagRoster {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
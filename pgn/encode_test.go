@@ -0,0 +1,95 @@
+package pgn
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/chess"
+)
+
+func TestEncode(t *testing.T) {
+	g := NewGame(nil)
+	g.SetTag("Event", "Test")
+	g.SetTag("Annotator", "honeybadger")
+
+	for _, uci := range []string{"e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6", "h5f7"} {
+		m, err := chess.MoveFromUCI(g.Position(), uci)
+		assert.Nil(t, err)
+		assert.Nil(t, g.AddMove(m))
+	}
+	g.Moves[3].NAGs = []int{2}
+	g.Moves[3].Comment = "Nf6 is better"
+	g.SetResult(WhiteWins)
+
+	want := `[Event "Test"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "1-0"]
+[Annotator "honeybadger"]
+
+1. e4 e5 2. Bc4 Nc6 $2 {Nf6 is better} 3. Qh5 Nf6 4. Qxf7# 1-0
+
+`
+
+	var buf bytes.Buffer
+	assert.Nil(t, Encode(&buf, g))
+	assert.Equal(t, want, buf.String())
+}
+
+func TestGame_AddMove(t *testing.T) {
+	g := NewGame(chess.StartingPosition())
+	m, _ := chess.MoveFromUCI(chess.StartingPosition(), "e2e5")
+	assert.NotNil(t, g.AddMove(m))
+	assert.Len(t, g.Moves, 0)
+
+	_, ok := g.Tag("FEN")
+	assert.False(t, ok)
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	data, err := os.ReadFile("../test/data/pgn/games.pgn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []*Game
+	for s := NewScanner(bytes.NewReader(data)); s.Scan(); {
+		want = append(want, s.Game())
+	}
+
+	var buf bytes.Buffer
+	for _, g := range want {
+		assert.Nil(t, Encode(&buf, g))
+	}
+
+	var got []*Game
+	s := NewScanner(&buf)
+	for s.Scan() {
+		got = append(got, s.Game())
+	}
+
+	assert.Nil(t, s.Err())
+	if assert.Len(t, got, len(want)) {
+		for i := range want {
+			assert.Equal(t, want[i].String(), got[i].String())
+			assert.Equal(t, want[i].Moves, got[i].Moves)
+			assert.Equal(t, want[i].Comment, got[i].Comment)
+		}
+	}
+}
+
+func TestEncode_Variations(t *testing.T) {
+	s := NewScanner(bytes.NewBufferString(`[FEN "8/P6k/8/8/8/8/8/K7 w - - 0 60"]
+
+60. a8=Q (60. a8=N Kg6) 60... Kg6 {forced} 61. Qg8+ *`))
+	assert.True(t, s.Scan())
+
+	want := "60. a8=Q (60. a8=N Kg6) 60... Kg6 {forced} 61. Qg8+ *"
+	assert.Contains(t, s.Game().String(), want)
+}
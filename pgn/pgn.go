@@ -0,0 +1,156 @@
+// Package pgn implements reading and writing of games in the Portable Game
+// Notation format.
+//
+// Games are replayed on the internal chess package, each move is validated
+// while reading.
+package pgn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leonhfr/honeybadger/chess"
+)
+
+var errIllegalMove = errors.New("illegal move")
+
+// Result tokens of a game.
+const (
+	WhiteWins = "1-0"     // WhiteWins represents a game won by white.
+	BlackWins = "0-1"     // BlackWins represents a game won by black.
+	Draw      = "1/2-1/2" // Draw represents a drawn game.
+	NoResult  = "*"       // NoResult represents an unknown or ongoing game.
+)
+
+// sevenTagRoster contains the mandatory tags in their export order.
+var sevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// unknownTagValue returns the value of an unknown tag of the seven tag roster.
+func unknownTagValue(name string) string {
+	if name == "Date" {
+		return "????.??.??"
+	}
+	return "?"
+}
+
+// Tag represents a tag pair.
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Move represents a move in the movetext along with its annotations.
+type Move struct {
+	Move       chess.Move // Move played.
+	NAGs       []int      // Numeric Annotation Glyphs.
+	Comment    string     // Comment following the move.
+	Variations [][]Move   // Alternatives to this move, played from the same position.
+}
+
+// Game represents a game record.
+type Game struct {
+	Tags    []Tag  // Tag pairs, in the order they were read or set.
+	Comment string // Comment preceding the movetext.
+	Moves   []Move // Main line.
+	Result  string // Game termination marker.
+	start   *chess.Position
+}
+
+// NewGame returns a new game starting from the provided position.
+//
+// If pos is nil, the game starts from the standard starting position.
+func NewGame(pos *chess.Position) *Game {
+	g := &Game{Result: NoResult}
+	for _, name := range sevenTagRoster {
+		g.Tags = append(g.Tags, Tag{Name: name, Value: unknownTagValue(name)})
+	}
+	g.SetResult(NoResult)
+
+	if pos == nil {
+		g.start = chess.StartingPosition()
+		return g
+	}
+
+	g.start = pos.Copy()
+	if fen := pos.String(); fen != chess.StartingPosition().String() {
+		g.SetTag("SetUp", "1")
+		g.SetTag("FEN", fen)
+	}
+	return g
+}
+
+// Tag returns the value of the tag and whether it was found.
+func (g *Game) Tag(name string) (string, bool) {
+	for _, tag := range g.Tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetTag sets the value of a tag, adding it if needed.
+func (g *Game) SetTag(name, value string) {
+	for i, tag := range g.Tags {
+		if tag.Name == name {
+			g.Tags[i].Value = value
+			return
+		}
+	}
+	g.Tags = append(g.Tags, Tag{Name: name, Value: value})
+}
+
+// SetResult sets the game termination marker and the Result tag.
+func (g *Game) SetResult(result string) {
+	g.Result = result
+	g.SetTag("Result", result)
+}
+
+// StartingPosition returns a copy of the position the game starts from.
+func (g *Game) StartingPosition() *chess.Position {
+	if g.start == nil {
+		return chess.StartingPosition()
+	}
+	return g.start.Copy()
+}
+
+// Position returns the position at the end of the main line.
+func (g *Game) Position() *chess.Position {
+	pos := g.StartingPosition()
+	for _, m := range g.Moves {
+		pos.MakeMove(m.Move)
+	}
+	return pos
+}
+
+// Positions returns the sequence of positions of the main line, starting
+// with the initial position and followed by the position after each move.
+func (g *Game) Positions() []*chess.Position {
+	pos := g.StartingPosition()
+	positions := []*chess.Position{pos.Copy()}
+	for _, m := range g.Moves {
+		pos.MakeMove(m.Move)
+		positions = append(positions, pos.Copy())
+	}
+	return positions
+}
+
+// AddMove plays a move at the end of the main line.
+func (g *Game) AddMove(m chess.Move) error {
+	pos := g.Position()
+	if !isLegal(pos, m) {
+		return fmt.Errorf("%w (%s)", errIllegalMove, m)
+	}
+	g.Moves = append(g.Moves, Move{Move: m})
+	return nil
+}
+
+// isLegal checks whether the move is one of the legal moves of the position.
+func isLegal(pos *chess.Position, m chess.Move) bool {
+	for _, legal := range pos.LegalMoves() {
+		if legal == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,419 @@
+package pgn
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/leonhfr/honeybadger/chess"
+)
+
+var (
+	errUnterminatedTag     = errors.New("unterminated tag pair")
+	errUnterminatedComment = errors.New("unterminated comment")
+	errUnbalancedVariation = errors.New("unbalanced variation")
+)
+
+// suffixNAGs maps the traditional suffix annotations to their NAG.
+var suffixNAGs = map[string]int{
+	"!":  1,
+	"?":  2,
+	"!!": 3,
+	"??": 4,
+	"!?": 5,
+	"?!": 6,
+}
+
+// Scanner reads games from a multi-game PGN stream.
+//
+// Successive calls to Scan step through the games of the stream.
+// Scanning stops at the end of the stream or at the first error.
+type Scanner struct {
+	lexer *lexer
+	game  *Game
+	err   error
+}
+
+// NewScanner returns a new Scanner reading from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{lexer: newLexer(r)}
+}
+
+// Scan advances the Scanner to the next game, which will then be available
+// through the Game method. It returns false when there are no more games,
+// either by reaching the end of the input or an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	game, err := s.parseGame()
+	if err != nil {
+		s.err = err
+		return false
+	}
+
+	s.game = game
+	return game != nil
+}
+
+// Game returns the most recent game read by a call to Scan.
+func (s *Scanner) Game() *Game {
+	return s.game
+}
+
+// Err returns the first error that was encountered by the Scanner.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// line represents a line of moves being parsed, either the main line
+// or a variation.
+type line struct {
+	moves   []Move
+	pos     *chess.Position // position after the last move
+	prev    *chess.Position // position before the last move
+	pending string          // comment preceding the first move of a variation
+}
+
+// parseGame parses the next game. Returns nil if there are no more games.
+func (s *Scanner) parseGame() (*Game, error) {
+	game := &Game{Result: NoResult}
+	var (
+		lines   []*line
+		started bool
+	)
+
+	current := func() *line { return lines[len(lines)-1] }
+
+	for {
+		tok, err := s.lexer.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok.kind == tokenEOF || tok.kind == tokenTag && started {
+			break
+		}
+
+		if tok.kind != tokenTag && !started {
+			if err := game.initStart(); err != nil {
+				return nil, err
+			}
+			lines = []*line{{pos: game.start.Copy()}}
+			started = true
+		}
+
+		s.lexer.next()
+
+		switch tok.kind {
+		case tokenTag:
+			game.Tags = append(game.Tags, Tag{Name: tok.name, Value: tok.value})
+		case tokenComment:
+			l := current()
+			switch n := len(l.moves); {
+			case n > 0:
+				l.moves[n-1].Comment = joinComments(l.moves[n-1].Comment, tok.value)
+			case len(lines) == 1:
+				game.Comment = joinComments(game.Comment, tok.value)
+			default:
+				// leading comment of a variation, attached to its first move
+				l.pending = joinComments(l.pending, tok.value)
+			}
+		case tokenNAG:
+			nag, err := strconv.Atoi(tok.value)
+			if err != nil || nag < 0 || nag > 255 {
+				return nil, fmt.Errorf("invalid NAG ($%s)", tok.value)
+			}
+			if l := current(); len(l.moves) > 0 {
+				l.moves[len(l.moves)-1].NAGs = append(l.moves[len(l.moves)-1].NAGs, nag)
+			}
+		case tokenOpenVariation:
+			l := current()
+			if len(l.moves) == 0 {
+				return nil, errUnbalancedVariation
+			}
+			lines = append(lines, &line{pos: l.prev.Copy()})
+		case tokenCloseVariation:
+			if len(lines) == 1 {
+				return nil, errUnbalancedVariation
+			}
+			variation := current()
+			lines = lines[:len(lines)-1]
+			parent := current()
+			last := &parent.moves[len(parent.moves)-1]
+			last.Variations = append(last.Variations, variation.moves)
+		case tokenResult:
+			if len(lines) > 1 {
+				return nil, errUnbalancedVariation
+			}
+			game.Result = tok.value
+			game.Moves = lines[0].moves
+			return game, nil
+		case tokenMove:
+			if err := current().play(tok.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !started {
+		if len(game.Tags) == 0 {
+			return nil, nil
+		}
+		if err := game.initStart(); err != nil {
+			return nil, err
+		}
+		return game, nil
+	}
+
+	if len(lines) > 1 {
+		return nil, errUnbalancedVariation
+	}
+
+	if result, ok := game.Tag("Result"); ok {
+		game.Result = result
+	}
+	game.Moves = lines[0].moves
+	return game, nil
+}
+
+// initStart sets the starting position of the game from the tags.
+func (g *Game) initStart() error {
+	fen, ok := g.Tag("FEN")
+	if !ok {
+		g.start = chess.StartingPosition()
+		return nil
+	}
+
+	pos, err := chess.FromFEN(fen)
+	if err != nil {
+		return err
+	}
+	g.start = pos
+	return nil
+}
+
+// play decodes a SAN move and plays it on the line.
+func (l *line) play(san string) error {
+	san, nag := splitSuffix(san)
+
+	m, err := chess.MoveFromSAN(l.pos, san)
+	if err != nil {
+		return fmt.Errorf("invalid move (%s): %w", san, err)
+	}
+
+	move := Move{Move: m, Comment: l.pending}
+	if nag > 0 {
+		move.NAGs = append(move.NAGs, nag)
+	}
+
+	l.pending = ""
+	l.prev = l.pos.Copy()
+	l.pos.MakeMove(m)
+	l.moves = append(l.moves, move)
+	return nil
+}
+
+// splitSuffix splits the SAN move from its suffix annotation.
+func splitSuffix(san string) (string, int) {
+	trimmed := strings.TrimRight(san, "!?")
+	return trimmed, suffixNAGs[san[len(trimmed):]]
+}
+
+func joinComments(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + " " + b
+}
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTag
+	tokenComment
+	tokenNAG
+	tokenOpenVariation
+	tokenCloseVariation
+	tokenResult
+	tokenMove
+)
+
+type token struct {
+	kind  tokenKind
+	name  string // tag name
+	value string
+}
+
+// lexer splits a PGN stream into tokens.
+type lexer struct {
+	reader    *bufio.Reader
+	lineStart bool
+	peeked    *token
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{reader: bufio.NewReader(r), lineStart: true}
+}
+
+// peek returns the next token without consuming it.
+func (l *lexer) peek() (token, error) {
+	if l.peeked != nil {
+		return *l.peeked, nil
+	}
+
+	tok, err := l.scan()
+	if err != nil {
+		return token{}, err
+	}
+	l.peeked = &tok
+	return tok, nil
+}
+
+// next consumes the peeked token.
+func (l *lexer) next() {
+	l.peeked = nil
+}
+
+func (l *lexer) read() (rune, bool) {
+	r, _, err := l.reader.ReadRune()
+	if err != nil {
+		return 0, false
+	}
+	l.lineStart = r == '\n'
+	return r, true
+}
+
+func (l *lexer) unread() {
+	_ = l.reader.UnreadRune()
+}
+
+// scan reads the next token from the stream.
+func (l *lexer) scan() (token, error) {
+	for {
+		lineStart := l.lineStart
+		r, ok := l.read()
+		if !ok {
+			return token{kind: tokenEOF}, nil
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+		case r == '%' && lineStart: // escape mechanism
+			l.readUntil('\n')
+		case r == '[':
+			return l.scanTag()
+		case r == '{':
+			comment, ok := l.readUntil('}')
+			if !ok {
+				return token{}, errUnterminatedComment
+			}
+			return token{kind: tokenComment, value: strings.Join(strings.Fields(comment), " ")}, nil
+		case r == ';':
+			comment, _ := l.readUntil('\n')
+			return token{kind: tokenComment, value: strings.TrimSpace(comment)}, nil
+		case r == '(':
+			return token{kind: tokenOpenVariation}, nil
+		case r == ')':
+			return token{kind: tokenCloseVariation}, nil
+		case r == '$':
+			return token{kind: tokenNAG, value: l.readSymbol()}, nil
+		default:
+			l.unread()
+			if tok, ok := symbolToken(l.readSymbol()); ok {
+				return tok, nil
+			}
+		}
+	}
+}
+
+// scanTag reads a tag pair after its opening bracket.
+func (l *lexer) scanTag() (token, error) {
+	var sb strings.Builder
+	var quoted, escaped bool
+	for {
+		r, ok := l.read()
+		if !ok {
+			return token{}, errUnterminatedTag
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+			continue
+		case r == '"':
+			quoted = !quoted
+		case r == ']' && !quoted:
+			content := strings.TrimSpace(sb.String())
+			i := strings.IndexFunc(content, unicode.IsSpace)
+			if i < 0 {
+				return token{}, fmt.Errorf("invalid tag pair (%s)", content)
+			}
+			value := strings.TrimSpace(content[i:])
+			if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+				return token{}, fmt.Errorf("invalid tag pair (%s)", content)
+			}
+			return token{kind: tokenTag, name: content[:i], value: value[1 : len(value)-1]}, nil
+		}
+
+		sb.WriteRune(r)
+	}
+}
+
+// readUntil reads until the delimiter, which is consumed but not returned.
+func (l *lexer) readUntil(delim rune) (string, bool) {
+	var sb strings.Builder
+	for {
+		r, ok := l.read()
+		if !ok {
+			return sb.String(), false
+		}
+		if r == delim {
+			return sb.String(), true
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// readSymbol reads a symbol token.
+func (l *lexer) readSymbol() string {
+	var sb strings.Builder
+	for {
+		r, ok := l.read()
+		if !ok {
+			return sb.String()
+		}
+		if unicode.IsSpace(r) || strings.ContainsRune("[]{}();$", r) {
+			l.unread()
+			l.lineStart = false
+			return sb.String()
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// symbolToken classifies a symbol. Move numbers are skipped.
+func symbolToken(symbol string) (token, bool) {
+	switch symbol {
+	case WhiteWins, BlackWins, Draw, NoResult:
+		return token{kind: tokenResult, value: symbol}, true
+	}
+
+	// move number indication, possibly followed by a move: 1. 1... 1.e4
+	if move := strings.TrimLeft(symbol, "0123456789"); strings.HasPrefix(move, ".") {
+		symbol = strings.TrimLeft(move, ".")
+	}
+
+	if symbol == "" {
+		return token{}, false
+	}
+	return token{kind: tokenMove, value: symbol}, true
+}
@@ -0,0 +1,113 @@
+package pgn
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner(t *testing.T) {
+	f, err := os.Open("../test/data/pgn/games.pgn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var games []*Game
+	for s := NewScanner(f); s.Scan(); {
+		games = append(games, s.Game())
+	}
+
+	if !assert.Len(t, games, 3) {
+		return
+	}
+
+	t.Run("Fischer Spassky", func(t *testing.T) {
+		g := games[0]
+		white, _ := g.Tag("White")
+		assert.Equal(t, "Fischer, Robert J.", white)
+		assert.Equal(t, Draw, g.Result)
+		assert.Len(t, g.Moves, 85)
+		assert.Equal(t, "This opening is called the Ruy Lopez.", g.Moves[5].Comment)
+		positions := g.Positions()
+		assert.Len(t, positions, 86)
+		assert.Equal(t, "Re6", g.Moves[84].Move.SAN(positions[84]))
+	})
+
+	t.Run("annotations", func(t *testing.T) {
+		g := games[1]
+		assert.Equal(t, "Scholar's mate.", g.Comment)
+		assert.Equal(t, WhiteWins, g.Result)
+		assert.Equal(t, []string{"e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6", "h5f7"}, uciMoves(g.Moves))
+
+		assert.Equal(t, []int{1}, g.Moves[2].NAGs)
+		assert.Equal(t, []int{5}, g.Moves[4].NAGs)
+		assert.Equal(t, []int{4}, g.Moves[5].NAGs)
+		assert.Equal(t, "loses on the spot", g.Moves[5].Comment)
+
+		assert.Len(t, g.Moves[3].Variations, 1)
+		assert.Equal(t, []string{"g8f6", "d2d3"}, uciMoves(g.Moves[3].Variations[0]))
+		assert.Equal(t, "is safer", g.Moves[3].Variations[0][0].Comment)
+
+		variation := g.Moves[5].Variations[0]
+		assert.Equal(t, []string{"g7g6", "h5f3", "g8f6", "f3b3"}, uciMoves(variation))
+		assert.Equal(t, []string{"d8f6"}, uciMoves(variation[2].Variations[0]))
+
+		assert.True(t, g.Position().InCheck())
+		assert.False(t, g.Position().HasLegalMoves())
+	})
+
+	t.Run("setup", func(t *testing.T) {
+		g := games[2]
+		assert.Equal(t, NoResult, g.Result)
+		assert.Equal(t, "8/P6k/8/8/8/8/8/K7 w - - 0 60", g.StartingPosition().String())
+		assert.Equal(t, []string{"a7a8q", "h7g6", "a8g8"}, uciMoves(g.Moves))
+	})
+}
+
+func TestScanner_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+	}{
+		{"illegal move", "1. e4 e4 *"},
+		{"unterminated comment", "1. e4 {comment *"},
+		{"unterminated tag", `[Event "test`},
+		{"invalid tag", `[Event]`},
+		{"unbalanced variation", "1. e4 (1. d4 *"},
+		{"closing variation", "1. e4 ) *"},
+		{"invalid fen", "[FEN \"8/8 w - - 0 1\"]\n\n*"},
+		{"non-numeric nag", "1. e4 $x *"},
+		{"out of range nag", "1. e4 $256 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(strings.NewReader(tt.args))
+			assert.False(t, s.Scan())
+			assert.NotNil(t, s.Err())
+		})
+	}
+}
+
+func TestScanner_NoTags(t *testing.T) {
+	s := NewScanner(strings.NewReader("1.e4 1...e5 2.Nf3 *\n\n1. d4 d5 1-0\n"))
+
+	var games [][]string
+	for s.Scan() {
+		games = append(games, uciMoves(s.Game().Moves))
+	}
+
+	assert.Nil(t, s.Err())
+	assert.Equal(t, [][]string{{"e2e4", "e7e5", "g1f3"}, {"d2d4", "d7d5"}}, games)
+}
+
+func uciMoves(moves []Move) []string {
+	var s []string
+	for _, m := range moves {
+		s = append(s, m.Move.String())
+	}
+	return s
+}
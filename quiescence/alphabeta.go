@@ -3,7 +3,10 @@ package quiescence
 import (
 	"context"
 
+	"github.com/notnil/chess"
+
 	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
 	"github.com/leonhfr/honeybadger/transposition"
 )
 
@@ -32,8 +35,9 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 
 	alphaOriginal := input.Alpha
 
+	// entries of the main search are at least as deep as the quiescence search
 	entry, cached := input.Transposition.Get(input.Position)
-	if cached && entry.Depth >= input.Depth {
+	if cached {
 		switch {
 		case entry.Flag == transposition.Exact:
 			return &Output{
@@ -75,7 +79,7 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 	}
 
 	moves := loudMoves(input.Position)
-	input.Oracle.Order(moves)
+	orderMoves(input, moves)
 
 	for _, move := range moves {
 		current, err := alphaBeta(ctx, Input{
@@ -115,11 +119,29 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 	case result.Score >= input.Beta:
 		flag = transposition.LowerBound
 	}
-	input.Transposition.Set(input.Position, transposition.Entry{
-		Score: result.Score,
-		Depth: input.Depth,
-		Flag:  flag,
-	})
+
+	// quiescence entries are stored at depth 0, below the entries of the main
+	// search, which are not replaced as they are deeper and hold a best move
+	if !cached || entry.Depth == 0 {
+		input.Transposition.Set(input.Position, transposition.Entry{
+			Score: result.Score,
+			Depth: 0,
+			Flag:  flag,
+		})
+	}
 
 	return result, nil
 }
+
+// orderMoves sorts the moves with the oracle,
+// in the context of the node if the oracle uses it.
+func orderMoves(input Input, moves []*chess.Move) {
+	if o, ok := input.Oracle.(oracle.NodeOrderer); ok {
+		o.OrderNode(oracle.Node{
+			Position: input.Position,
+			Depth:    input.Depth,
+		}, moves)
+		return
+	}
+	input.Oracle.Order(moves)
+}
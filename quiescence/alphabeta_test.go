@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/notnil/chess"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/leonhfr/honeybadger/evaluation"
 	"github.com/leonhfr/honeybadger/oracle"
@@ -39,3 +40,61 @@ func BenchmarkAlphaBeta1(b *testing.B) {
 func BenchmarkAlphaBeta3(b *testing.B) {
 	benchmarkAlphaBeta("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3, b)
 }
+
+func TestAlphaBeta_Transposition(t *testing.T) {
+	pos := position("4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1")
+	input := Input{
+		Position:   pos,
+		Depth:      MaxDepth,
+		Alpha:      -evaluation.Mate,
+		Beta:       evaluation.Mate,
+		Evaluation: evaluation.Pesto{},
+		Oracle:     oracle.Order{},
+	}
+
+	t.Run("stores at depth 0", func(t *testing.T) {
+		input.Transposition = mapTransposition{}
+		_, err := alphaBeta(context.Background(), input)
+		assert.Nil(t, err)
+
+		entry, cached := input.Transposition.Get(pos)
+		assert.True(t, cached)
+		assert.Equal(t, 0, entry.Depth)
+	})
+
+	t.Run("keeps deeper entries", func(t *testing.T) {
+		deeper := transposition.Entry{
+			Score: 100,
+			Depth: 2,
+			Flag:  transposition.LowerBound,
+			Move:  pos.ValidMoves()[0],
+		}
+		input.Transposition = mapTransposition{}
+		input.Transposition.Set(pos, deeper)
+		_, err := alphaBeta(context.Background(), input)
+		assert.Nil(t, err)
+
+		entry, cached := input.Transposition.Get(pos)
+		assert.True(t, cached)
+		assert.Equal(t, deeper, entry)
+	})
+}
+
+// mapTransposition is a transposition table backed by a map,
+// which unlike Ristretto stores every entry synchronously.
+type mapTransposition map[[16]byte]transposition.Entry
+
+func (mapTransposition) String() string { return "Map" }
+
+func (mapTransposition) Init(size int) error { return nil }
+
+func (m mapTransposition) Set(key *chess.Position, entry transposition.Entry) {
+	m[key.Hash()] = entry
+}
+
+func (m mapTransposition) Get(key *chess.Position) (transposition.Entry, bool) {
+	entry, ok := m[key.Hash()]
+	return entry, ok
+}
+
+func (mapTransposition) Close() {}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/notnil/chess"
 
+	ichess "github.com/leonhfr/honeybadger/chess"
 	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
 	"github.com/leonhfr/honeybadger/quiescence"
 	"github.com/leonhfr/honeybadger/transposition"
 )
@@ -22,17 +24,7 @@ func (AlphaBeta) String() string {
 // Search implements the Interface interface.
 func (AlphaBeta) Search(ctx context.Context, input Input, output chan<- *Output) {
 	for depth := 1; depth <= input.Depth; depth++ {
-		o, err := alphaBeta(ctx, Input{
-			Position:      input.Position,
-			SearchMoves:   input.SearchMoves,
-			Depth:         depth,
-			alpha:         -evaluation.Mate,
-			beta:          evaluation.Mate,
-			Evaluation:    input.Evaluation,
-			Oracle:        input.Oracle,
-			Quiescence:    input.Quiescence,
-			Transposition: input.Transposition,
-		})
+		o, err := alphaBeta(ctx, input.root(depth, -evaluation.Mate, evaluation.Mate))
 		if err != nil {
 			return
 		}
@@ -52,85 +44,79 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 
 	alphaOriginal := input.alpha
 
-	entry, cached := input.Transposition.Get(input.Position)
-	if cached && entry.Depth >= input.Depth {
-		switch {
-		case entry.Flag == transposition.Exact:
-			return &Output{
-				Nodes: 1,
-				Score: entry.Score,
-			}, nil
-		case entry.Flag == transposition.LowerBound && entry.Score > input.alpha:
-			input.alpha = entry.Score
-		case entry.Flag == transposition.UpperBound && entry.Score < input.beta:
-			input.beta = entry.Score
-		}
-
-		if input.alpha >= input.beta {
-			return &Output{
-				Nodes: 1,
-				Score: entry.Score,
-			}, nil
-		}
+	output, hashMove, ok := probeTransposition(&input)
+	if ok {
+		return output, nil
 	}
 
-	score, terminal := evaluation.Terminal(input.Position)
-	if terminal {
-		return &Output{
-			Nodes: 1,
-			Score: score,
-		}, nil
+	if output, ok, err := leaf(ctx, input); ok || err != nil {
+		return output, err
 	}
 
-	if input.Depth == 0 {
-		if quiescence.IsQuiet(input.Position) {
-			return &Output{
-				Nodes: 1,
-				Score: input.Evaluation.Evaluate(input.Position),
-			}, nil
+	pruning := &Output{}
+	eval, frontier := staticEvaluation(input)
+	if frontier {
+		var ok bool
+		var err error
+		pruning, ok, err = forwardPruning(ctx, input, eval)
+		if ok || err != nil {
+			return pruning, err
 		}
+	}
 
-		output, err := input.Quiescence.Search(ctx, quiescence.Input{
-			Position:      input.Position,
-			Depth:         quiescence.MaxDepth,
-			Alpha:         -input.beta,
-			Beta:          -input.alpha,
-			Evaluation:    input.Evaluation,
-			Oracle:        input.Oracle,
-			Transposition: input.Transposition,
-		})
-		if err != nil {
-			return nil, err
-		}
+	null, ok, err := nullMovePruning(ctx, input, alphaBeta)
+	if ok || err != nil {
+		return null, err
+	}
 
-		return &Output{
-			Nodes: output.Nodes,
-			Score: output.Score,
-		}, nil
+	singular, singularNodes, err := singularExtension(ctx, input, hashMove, alphaBeta)
+	if err != nil {
+		return nil, err
 	}
 
 	result := &Output{
 		Depth: input.Depth,
-		Nodes: 0,
+		Nodes: pruning.Nodes + null.Nodes + singularNodes,
 		Score: -evaluation.Mate,
 	}
 
-	moves := searchMoves(input)
-	input.Oracle.Order(moves)
+	picker := newMovePicker(input, hashMove)
+	for i := 0; ; i++ {
+		move, ok := picker.next()
+		if !ok {
+			break
+		}
 
-	for _, move := range moves {
-		current, err := alphaBeta(ctx, Input{
-			Position:      input.Position.Update(move),
-			Depth:         input.Depth - 1,
-			alpha:         -input.beta,
-			beta:          -input.alpha,
-			Evaluation:    input.Evaluation,
-			Oracle:        input.Oracle,
-			Quiescence:    input.Quiescence,
-			Transposition: input.Transposition,
-		})
-		if err != nil {
-			return nil, err
+		// extended moves are neither pruned nor reduced
+		plies := extension(input, move, singular && sameMove(move, hashMove))
+		if plies == 0 && (lateMovePruning(input, i, move, result.Score) ||
+			frontier && futilityPruning(input, move, eval, result.Score)) {
+			continue
+		}
+
+		var current *Output
+		var nodes int
+
+		if reduction := lateMoveReduction(input, i, move); reduction > 0 && plies == 0 {
+			reduced, err := alphaBeta(ctx, input.scoutChild(move, reduction))
+			if err != nil {
+				return nil, err
+			}
+
+			// the reduced search proved the move is not better
+			if -reduced.Score <= input.alpha {
+				current = reduced
+			}
+			nodes = reduced.Nodes
+		}
+
+		if current == nil {
+			full, err := alphaBeta(ctx, input.child(move, -input.beta, -input.alpha).extend(plies))
+			if err != nil {
+				return nil, err
+			}
+			full.Nodes += nodes
+			current = full
 		}
 
 		current.Score = -current.Score
@@ -145,11 +131,140 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 		}
 
 		if input.alpha >= input.beta {
+			recordCutoff(input, move)
 			break
 		}
 	}
 
 	result.Score = evaluation.IncMateDistance(result.Score, maxDepth)
+	storeTransposition(input, alphaOriginal, result)
+
+	return result, nil
+}
+
+// probeTransposition looks the position up in the transposition table.
+//
+// The bounds of the input are narrowed by the entry if it is deep enough.
+// Returns the output and true when the entry is enough to score the position,
+// along with the best move of the entry to search first otherwise.
+func probeTransposition(input *Input) (*Output, *chess.Move, bool) {
+	// the entry of the position was stored by a search of all its moves
+	if input.excluded != nil {
+		return nil, nil, false
+	}
+
+	entry, cached := input.Transposition.Get(input.Position)
+	if !cached {
+		return nil, nil, false
+	}
+
+	// the entry may have been stored by a search with other root moves
+	if entry.Depth < input.Depth || (input.ply == 0 && len(input.SearchMoves) > 0) {
+		return nil, entry.Move, false
+	}
+
+	switch {
+	case entry.Flag == transposition.Exact:
+		return transpositionOutput(input, entry), nil, true
+	case entry.Flag == transposition.LowerBound && entry.Score > input.alpha:
+		input.alpha = entry.Score
+	case entry.Flag == transposition.UpperBound && entry.Score < input.beta:
+		input.beta = entry.Score
+	}
+
+	if input.alpha >= input.beta {
+		return transpositionOutput(input, entry), nil, true
+	}
+
+	return nil, entry.Move, false
+}
+
+// transpositionOutput returns the output of a position scored by an entry
+// of the transposition table, with the principal variation of the table.
+func transpositionOutput(input *Input, entry transposition.Entry) *Output {
+	return &Output{
+		Nodes: 1,
+		Score: entry.Score,
+		PV:    transpositionPV(input.Transposition, input.Position, entry.Depth),
+	}
+}
+
+// transpositionPV returns the principal variation from the position that
+// follows the best moves of the transposition table, at most depth moves long.
+// It stops at a move that is not legal in its position.
+func transpositionPV(table transposition.Interface, position *chess.Position, depth int) []*chess.Move {
+	var pv []*chess.Move
+	seen := make(map[[16]byte]bool)
+	for len(pv) < depth && !seen[position.Hash()] {
+		entry, cached := table.Get(position)
+		if !cached || entry.Move == nil {
+			break
+		}
+
+		move := findMove(position.ValidMoves(), entry.Move)
+		if move == nil {
+			break
+		}
+
+		seen[position.Hash()] = true
+		pv = append(pv, move)
+		position = position.Update(move)
+	}
+	return pv
+}
+
+// leaf scores the position if it is terminal or if the depth is reached,
+// in which case the quiescence search takes over.
+//
+// Returns the output and true when the position is a leaf.
+func leaf(ctx context.Context, input Input) (*Output, bool, error) {
+	score, terminal := evaluation.Terminal(input.Position)
+	if terminal {
+		return &Output{
+			Nodes: 1,
+			Score: score,
+		}, true, nil
+	}
+
+	if input.Depth > 0 {
+		return nil, false, nil
+	}
+
+	if quiescence.IsQuiet(input.Position) {
+		return &Output{
+			Nodes: 1,
+			Score: input.Evaluation.Evaluate(input.Position),
+		}, true, nil
+	}
+
+	output, err := input.Quiescence.Search(ctx, quiescence.Input{
+		Position:      input.Position,
+		Depth:         quiescence.MaxDepth,
+		Alpha:         input.alpha,
+		Beta:          input.beta,
+		Evaluation:    input.Evaluation,
+		Oracle:        input.Oracle,
+		Transposition: input.Transposition,
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	return &Output{
+		Nodes: output.Nodes,
+		Score: output.Score,
+	}, true, nil
+}
+
+// storeTransposition stores the score and the best move of the position in
+// the transposition table, flagged according to the original bounds of the search.
+//
+// The best move is not stored when all moves failed low, as it is not reliable.
+// Results of searches with an excluded move are not stored.
+func storeTransposition(input Input, alphaOriginal int, result *Output) {
+	if input.excluded != nil {
+		return
+	}
 
 	flag := transposition.Exact
 	switch {
@@ -158,11 +273,114 @@ func alphaBeta(ctx context.Context, input Input) (*Output, error) {
 	case result.Score >= input.beta:
 		flag = transposition.LowerBound
 	}
+
+	var move *chess.Move
+	if flag != transposition.UpperBound && len(result.PV) > 0 {
+		move = result.PV[0]
+	}
+
 	input.Transposition.Set(input.Position, transposition.Entry{
 		Score: result.Score,
 		Depth: input.Depth,
 		Flag:  flag,
+		Move:  move,
 	})
+}
 
-	return result, nil
+// child returns the input to search the position after the move
+// one ply deeper with the bounds.
+func (input Input) child(move *chess.Move, alpha, beta int) Input {
+	return Input{
+		Position:           input.Position.Update(move),
+		Depth:              input.Depth - 1,
+		alpha:              alpha,
+		beta:               beta,
+		Evaluation:         input.Evaluation,
+		Oracle:             input.Oracle,
+		Quiescence:         input.Quiescence,
+		Transposition:      input.Transposition,
+		NullMove:           input.NullMove,
+		NullMoveVerify:     input.NullMoveVerify,
+		LateMoveReductions: input.LateMoveReductions,
+		Extensions:         input.Extensions,
+		ExtensionBudget:    input.ExtensionBudget,
+		Margins:            input.Margins,
+		ply:                input.ply + 1,
+		inCheck:            move.HasTag(chess.Check),
+		previous:           move,
+		extended:           input.extended,
+	}
+}
+
+// root returns the input to search the root position at the depth
+// with the window.
+//
+// Whether the current player is in check is only known from the previous
+// move below the root, so it is computed from the root position.
+func (input Input) root(depth, alpha, beta int) Input {
+	return Input{
+		Position:           input.Position,
+		SearchMoves:        input.SearchMoves,
+		Depth:              depth,
+		alpha:              alpha,
+		beta:               beta,
+		Evaluation:         input.Evaluation,
+		Oracle:             input.Oracle,
+		Quiescence:         input.Quiescence,
+		Transposition:      input.Transposition,
+		NullMove:           input.NullMove,
+		NullMoveVerify:     input.NullMoveVerify,
+		LateMoveReductions: input.LateMoveReductions,
+		Extensions:         input.Extensions,
+		ExtensionBudget:    input.ExtensionBudget,
+		Margins:            input.Margins,
+		inCheck:            isInCheck(input.Position),
+	}
+}
+
+// isInCheck reports whether the current player of the position is in check.
+func isInCheck(position *chess.Position) bool {
+	pos, err := ichess.FromFEN(position.String())
+	return err == nil && pos.Checkers() > 0
+}
+
+// scoutChild returns the input to search the position after the move
+// with a zero window above alpha, at a depth reduced by the reduction.
+func (input Input) scoutChild(move *chess.Move, reduction int) Input {
+	child := input.child(move, -input.alpha-1, -input.alpha)
+	child.Depth -= reduction
+	return child
+}
+
+// orderMoves sorts the moves with the oracle,
+// in the context of the node if the oracle uses it.
+func orderMoves(input Input, moves []*chess.Move) {
+	if o, ok := input.Oracle.(oracle.NodeOrderer); ok {
+		o.OrderNode(input.node(), moves)
+		return
+	}
+	input.Oracle.Order(moves)
+}
+
+// recordCutoff reports a beta cutoff to the oracle if it learns from them.
+func recordCutoff(input Input, move *chess.Move) {
+	if h, ok := input.Oracle.(oracle.Heuristic); ok {
+		h.Cutoff(input.node(), move)
+	}
+}
+
+// isKiller reports whether the move is a killer move for the oracle.
+func isKiller(input Input, move *chess.Move) bool {
+	h, ok := input.Oracle.(oracle.Heuristic)
+	return ok && h.Killer(input.ply, move)
+}
+
+// node returns the oracle context of the input.
+func (input Input) node() oracle.Node {
+	return oracle.Node{
+		Position: input.Position,
+		Previous: input.previous,
+		Ply:      input.ply,
+		Depth:    input.Depth,
+	}
 }
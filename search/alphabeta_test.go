@@ -33,22 +33,22 @@ func TestAlphaBeta(t *testing.T) {
 		{
 			name: "checkmate",
 			args: args{"8/8/8/5K1k/8/8/8/7R b - - 0 1", 1},
-			want: want{Output{0, 1, -evaluation.Mate, 0, nil}, nil, nil},
+			want: want{Output{0, 1, -evaluation.Mate, 0, Exact, nil, nil}, nil, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"8/8/8/5K1k/8/8/8/5R2 w - - 0 1", 1},
-			want: want{Output{1, 12, evaluation.Mate - 1, 0, nil}, []string{"f1h1"}, nil},
+			want: want{Output{1, 12, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f1h1"}, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"r1b1kb1r/pppp1ppp/2n1pq2/8/3Pn2N/2P3P1/PP1NPP1P/R1BQKB1R b KQkq - 3 6", 1},
-			want: want{Output{1, 3, evaluation.Mate - 1, 0, nil}, []string{"f6f2"}, nil},
+			want: want{Output{1, 3, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f6f2"}, nil},
 		},
 		{
 			name: "mate in 2",
 			args: args{"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3},
-			want: want{Output{3, 2995, evaluation.Mate - 3, 0, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
+			want: want{Output{3, 2995, evaluation.Mate - 3, 0, Exact, nil, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
 		},
 	}
 
@@ -102,22 +102,22 @@ func TestAlphaBetaWithOrder(t *testing.T) {
 		{
 			name: "checkmate",
 			args: args{"8/8/8/5K1k/8/8/8/7R b - - 0 1", 1},
-			want: want{Output{0, 1, -evaluation.Mate, 0, nil}, nil, nil},
+			want: want{Output{0, 1, -evaluation.Mate, 0, Exact, nil, nil}, nil, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"8/8/8/5K1k/8/8/8/5R2 w - - 0 1", 1},
-			want: want{Output{1, 1, evaluation.Mate - 1, 0, nil}, []string{"f1h1"}, nil},
+			want: want{Output{1, 1, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f1h1"}, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"r1b1kb1r/pppp1ppp/2n1pq2/8/3Pn2N/2P3P1/PP1NPP1P/R1BQKB1R b KQkq - 3 6", 1},
-			want: want{Output{1, 1, evaluation.Mate - 1, 0, nil}, []string{"f6f2"}, nil},
+			want: want{Output{1, 1, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f6f2"}, nil},
 		},
 		{
 			name: "mate in 2",
 			args: args{"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3},
-			want: want{Output{3, 467, evaluation.Mate - 3, 0, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
+			want: want{Output{3, 467, evaluation.Mate - 3, 0, Exact, nil, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
 		},
 	}
 
@@ -172,3 +172,66 @@ func BenchmarkAlphaBeta1(b *testing.B) {
 func BenchmarkAlphaBeta3(b *testing.B) {
 	benchmarkAlphaBeta("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3, b)
 }
+
+func TestAlphaBetaWithKillerHistory(t *testing.T) {
+	type (
+		args struct {
+			fen   string
+			depth int
+		}
+		want struct {
+			score   int
+			moves   []string
+			killers map[int]string // killer move learned at each ply
+		}
+	)
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "quiet mate in 2",
+			args: args{"kbK5/pp6/1P6/8/8/8/8/R7 w - - 0 1", 3},
+			want: want{evaluation.Mate - 3, []string{"a1a6", "b7a6", "b6b7"}, map[int]string{2: "b6b7"}},
+		},
+		{
+			name: "mate in 2",
+			args: args{"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3},
+			want: want{evaluation.Mate - 3, []string{"c6g2", "e2g2", "c1e1"}, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kh := &oracle.KillerHistory{}
+			output, err := alphaBeta(context.Background(), Input{
+				Position:      position(tt.args.fen),
+				Depth:         tt.args.depth,
+				alpha:         -evaluation.Mate,
+				beta:          evaluation.Mate,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        kh,
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+			})
+			assert.Nil(t, err)
+
+			var moves []string
+			for _, move := range output.PV {
+				moves = append(moves, move.String())
+			}
+			assert.Equal(t, tt.want.score, output.Score)
+			assert.Equal(t, tt.want.moves, moves)
+
+			for ply, killer := range tt.want.killers {
+				pos := position(tt.args.fen)
+				for _, move := range output.PV[:ply] {
+					pos = pos.Update(move)
+				}
+				assert.True(t, kh.Killer(ply, validMove(pos, killer)))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+
+	"github.com/notnil/chess"
+
+	ichess "github.com/leonhfr/honeybadger/chess"
+	searchv2 "github.com/leonhfr/honeybadger/search_v2"
+)
+
+// AlphaBetaV2 runs the alpha-beta search of the search_v2 package, which
+// makes and unmakes moves on the bitboard position of the chess package.
+//
+// It uses its own evaluation and move ordering: the evaluation, oracle,
+// quiescence and transposition strategies of the input are ignored.
+//
+// The position is converted through its FEN, which does not hold the moves
+// played before: the search does not know the game history, and repetitions
+// of positions reached before the root are not detected.
+//
+// When the position or a move cannot be converted, the search ends with an
+// output holding the error.
+type AlphaBetaV2 struct{}
+
+// String implements the Interface interface.
+func (AlphaBetaV2) String() string {
+	return "AlphaBetaV2"
+}
+
+// Search implements the Interface interface.
+func (AlphaBetaV2) Search(ctx context.Context, input Input, output chan<- *Output) {
+	pos, err := ichess.FromFEN(input.Position.String())
+	if err != nil {
+		output <- &Output{Err: err}
+		return
+	}
+
+	var searchMoves []ichess.Move
+	for _, move := range input.SearchMoves {
+		m, err := ichess.MoveFromUCI(pos, move.String())
+		if err != nil {
+			output <- &Output{Err: err}
+			return
+		}
+		searchMoves = append(searchMoves, m)
+	}
+
+	// stops the search if the output cannot be converted
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for o := range searchv2.Run(ctx, searchv2.Input{
+		Game:        ichess.NewGame(pos),
+		SearchMoves: searchMoves,
+		Depth:       input.Depth,
+	}) {
+		pv, err := pvFromV2(input.Position, o.PV)
+		if err != nil {
+			output <- &Output{Err: err}
+			return
+		}
+
+		output <- &Output{
+			Depth: o.Depth,
+			Nodes: o.Nodes,
+			Score: o.Score,
+			Mate:  mateIn(o.Score),
+			PV:    pv,
+		}
+	}
+}
+
+// pvFromV2 converts a principal variation played from the position
+// to moves of the notnil/chess package.
+func pvFromV2(position *chess.Position, moves []ichess.Move) ([]*chess.Move, error) {
+	var pv []*chess.Move
+	for _, move := range moves {
+		m, err := chess.UCINotation{}.Decode(position, move.String())
+		if err != nil {
+			return nil, err
+		}
+		pv = append(pv, m)
+		position = position.Update(m)
+	}
+	return pv, nil
+}
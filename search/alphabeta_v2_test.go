@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notnil/chess"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlphaBetaV2(t *testing.T) {
+	tests := []struct {
+		name   string
+		fen    string
+		depth  int
+		search []string
+		mate   int
+		moves  []string
+	}{
+		{
+			name:  "mate in 1",
+			fen:   "8/8/8/5K1k/8/8/8/5R2 w - - 0 1",
+			depth: 1,
+			mate:  1,
+			moves: []string{"f1h1"},
+		},
+		{
+			name:  "mate in 2",
+			fen:   "5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1",
+			depth: 3,
+			mate:  2,
+			moves: []string{"c6g2", "e2g2", "c1e1"},
+		},
+		{
+			name:   "search moves",
+			fen:    "8/8/8/5K1k/8/8/8/5R2 w - - 0 1",
+			depth:  1,
+			search: []string{"f1f2"},
+			moves:  []string{"f1f2"},
+		},
+		{
+			name:   "castle",
+			fen:    "4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+			depth:  1,
+			search: []string{"e1g1"},
+			moves:  []string{"e1g1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := position(tt.fen)
+
+			var searchMoves []*chess.Move
+			for _, move := range tt.search {
+				m, err := chess.UCINotation{}.Decode(pos, move)
+				assert.Nil(t, err)
+				searchMoves = append(searchMoves, m)
+			}
+
+			output := make(chan *Output)
+			go func() {
+				defer close(output)
+				AlphaBetaV2{}.Search(context.Background(), Input{
+					Position:    pos,
+					SearchMoves: searchMoves,
+					Depth:       tt.depth,
+				}, output)
+			}()
+
+			var outputs []*Output
+			for o := range output {
+				outputs = append(outputs, o)
+			}
+
+			assert.Len(t, outputs, tt.depth)
+			last := outputs[len(outputs)-1]
+			assert.Equal(t, tt.mate, last.Mate)
+
+			var moves []string
+			for _, move := range last.PV {
+				moves = append(moves, move.String())
+			}
+			assert.Equal(t, tt.moves, moves)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+
+	"github.com/notnil/chess"
+
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+// Extension represents a set of search extensions.
+//
+// An extended move is searched one ply deeper than the other moves,
+// so that forcing lines are seen further. The plies of extension along
+// a line are capped by the extension budget of the input.
+type Extension uint8
+
+const (
+	// CheckExtension extends moves that give check.
+	CheckExtension Extension = 1 << iota
+	// RecaptureExtension extends captures on the square
+	// where the previous move captured.
+	RecaptureExtension
+	// PassedPawnExtension extends pawn moves to the seventh rank.
+	PassedPawnExtension
+	// SingularExtension extends the hash move when it is singular:
+	// when all the other moves fail low by a margin on a reduced search.
+	SingularExtension
+	// AllExtensions represents all extensions.
+	AllExtensions = CheckExtension | RecaptureExtension | PassedPawnExtension | SingularExtension
+)
+
+const (
+	// singularDepth is the minimum depth at which singular extensions are tried.
+	singularDepth = 6
+	// singularMargin is the margin per ply of depth by which
+	// the other moves must fail low for the hash move to be singular.
+	singularMargin = 2
+)
+
+// extension returns the number of plies by which the move is extended,
+// 0 when the extension budget of the line is spent.
+func extension(input Input, move *chess.Move, singular bool) int {
+	if input.extended >= input.ExtensionBudget {
+		return 0
+	}
+
+	switch {
+	case input.Extensions&CheckExtension != 0 && move.HasTag(chess.Check),
+		input.Extensions&RecaptureExtension != 0 && isRecapture(input.previous, move),
+		input.Extensions&PassedPawnExtension != 0 && isPawnToSeventh(input.Position, move),
+		singular:
+		return 1
+	}
+
+	return 0
+}
+
+// singularExtension reports whether the hash move is singular.
+//
+// The other moves are searched with a zero window below the score of the
+// transposition entry lowered by a margin, at half the depth. The hash move
+// is singular if they all fail low. The entry must be a lower bound or an
+// exact score not much shallower than the node.
+//
+// Returns the number of nodes searched along with the result.
+func singularExtension(ctx context.Context, input Input, hashMove *chess.Move, search func(context.Context, Input) (*Output, error)) (bool, int, error) {
+	if input.Extensions&SingularExtension == 0 || hashMove == nil || input.excluded != nil ||
+		input.ply == 0 || input.Depth < singularDepth || input.extended >= input.ExtensionBudget {
+		return false, 0, nil
+	}
+
+	entry, cached := input.Transposition.Get(input.Position)
+	if !cached || entry.Flag == transposition.UpperBound || entry.Depth < input.Depth-3 ||
+		isMateScore(entry.Score) {
+		return false, 0, nil
+	}
+
+	beta := entry.Score - singularMargin*input.Depth
+	excluded := input
+	excluded.Depth = (input.Depth - 1) / 2
+	excluded.alpha, excluded.beta = beta-1, beta
+	excluded.skipNull = true
+	excluded.excluded = hashMove
+
+	output, err := search(ctx, excluded)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return output.Score < beta, output.Nodes, nil
+}
+
+// isRecapture reports whether the move captures on the square
+// where the previous move captured.
+func isRecapture(previous, move *chess.Move) bool {
+	return previous != nil && previous.HasTag(chess.Capture) &&
+		move.HasTag(chess.Capture) && move.S2() == previous.S2()
+}
+
+// isPawnToSeventh reports whether the move brings a pawn to the seventh rank
+// relative to its color. Such a pawn is always passed, as no opposing pawn
+// can stand in front of it.
+func isPawnToSeventh(position *chess.Position, move *chess.Move) bool {
+	piece := position.Board().Piece(move.S1())
+	if piece.Type() != chess.Pawn {
+		return false
+	}
+
+	if piece.Color() == chess.White {
+		return move.S2().Rank() == chess.Rank7
+	}
+	return move.S2().Rank() == chess.Rank2
+}
+
+// extend returns the input searched deeper by the plies of extension.
+func (input Input) extend(plies int) Input {
+	input.Depth += plies
+	input.extended += plies
+	return input
+}
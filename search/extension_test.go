@@ -0,0 +1,182 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+func TestExtension(t *testing.T) {
+	previous := position("4k3/8/8/3p4/4N3/8/8/4K3 b - - 0 1")
+	capture := validMove(previous, "d5e4")
+
+	tests := []struct {
+		name       string
+		fen        string
+		move       string
+		extensions Extension
+		budget     int
+		singular   bool
+		want       int
+	}{
+		{"quiet", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a7", AllExtensions, 8, false, 0},
+		{"check", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", AllExtensions, 8, false, 1},
+		{"check disabled", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", AllExtensions &^ CheckExtension, 8, false, 0},
+		{"budget spent", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", AllExtensions, 0, false, 0},
+		{"recapture", "4k3/8/8/8/4p3/5P2/8/4K3 w - - 0 1", "f3e4", AllExtensions, 8, false, 1},
+		{"recapture disabled", "4k3/8/8/8/4p3/5P2/8/4K3 w - - 0 1", "f3e4", AllExtensions &^ RecaptureExtension, 8, false, 0},
+		{"white pawn to seventh", "4k3/8/1P6/8/8/8/8/4K3 w - - 0 1", "b6b7", AllExtensions, 8, false, 1},
+		{"black pawn to second", "4k3/8/8/8/8/1p6/8/4K3 b - - 0 1", "b3b2", AllExtensions, 8, false, 1},
+		{"pawn to sixth", "4k3/8/8/1P6/8/8/8/4K3 w - - 0 1", "b5b6", AllExtensions, 8, false, 0},
+		{"passed pawn disabled", "4k3/8/1P6/8/8/8/8/4K3 w - - 0 1", "b6b7", AllExtensions &^ PassedPawnExtension, 8, false, 0},
+		{"singular", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a7", AllExtensions, 8, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := position(tt.fen)
+			input := Input{
+				Position:        pos,
+				Extensions:      tt.extensions,
+				ExtensionBudget: tt.budget,
+				previous:        capture,
+			}
+			assert.Equal(t, tt.want, extension(input, validMove(pos, tt.move), tt.singular))
+		})
+	}
+}
+
+func TestSingularExtension(t *testing.T) {
+	pos := position("4k3/8/8/8/3q4/8/3R4/4K3 w - - 0 1")
+	hashMove := validMove(pos, "d2d4")
+
+	type args struct {
+		extensions Extension
+		depth      int
+		ply        int
+		extended   int
+		excluded   bool
+		entry      *transposition.Entry
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "singular",
+			args: args{AllExtensions, 6, 1, 0, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: true,
+		},
+		{
+			name: "disabled",
+			args: args{CheckExtension, 6, 1, 0, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "root",
+			args: args{AllExtensions, 6, 0, 0, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "shallow",
+			args: args{AllExtensions, singularDepth - 1, 1, 0, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "budget spent",
+			args: args{AllExtensions, 6, 1, 8, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "excluded search",
+			args: args{AllExtensions, 6, 1, 0, true, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.LowerBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "no entry",
+			args: args{AllExtensions, 6, 1, 0, false, nil},
+			want: false,
+		},
+		{
+			name: "upper bound",
+			args: args{AllExtensions, 6, 1, 0, false, &transposition.Entry{Score: 500, Depth: 6, Flag: transposition.UpperBound, Move: hashMove}},
+			want: false,
+		},
+		{
+			name: "not singular",
+			args: args{AllExtensions, 6, 1, 0, false, &transposition.Entry{Score: -2000, Depth: 6, Flag: transposition.Exact, Move: hashMove}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := mapTransposition{}
+			if tt.args.entry != nil {
+				table.Set(pos, *tt.args.entry)
+			}
+
+			input := Input{
+				Position:        pos,
+				Depth:           tt.args.depth,
+				alpha:           -evaluation.Mate,
+				beta:            evaluation.Mate,
+				Evaluation:      evaluation.Pesto{},
+				Oracle:          oracle.Order{},
+				Quiescence:      quiescence.None{},
+				Transposition:   table,
+				Extensions:      tt.args.extensions,
+				ExtensionBudget: 8,
+				ply:             tt.args.ply,
+				extended:        tt.args.extended,
+			}
+			if tt.args.excluded {
+				input.excluded = hashMove
+			}
+
+			got, _, err := singularExtension(context.Background(), input, hashMove, alphaBeta)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtensions_Search(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions Extension
+		budget     int
+		mate       bool
+	}{
+		{"no extension", 0, 8, false},
+		{"check extension", CheckExtension, 8, true},
+		{"no budget", CheckExtension, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := alphaBeta(context.Background(), Input{
+				Position:        position("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1"),
+				Depth:           2,
+				alpha:           -evaluation.Mate,
+				beta:            evaluation.Mate,
+				Evaluation:      evaluation.Pesto{},
+				Oracle:          oracle.Order{},
+				Quiescence:      quiescence.None{},
+				Transposition:   transposition.None{},
+				Extensions:      tt.extensions,
+				ExtensionBudget: tt.budget,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.mate, output.Score == evaluation.Mate-3)
+		})
+	}
+}
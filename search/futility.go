@@ -0,0 +1,122 @@
+package search
+
+import (
+	"context"
+
+	"github.com/notnil/chess"
+)
+
+// Margins holds the margins of the forward pruning techniques
+// in centipawns per ply of depth. A zero margin disables the technique.
+type Margins struct {
+	Futility        int // Futility pruning margin.
+	ReverseFutility int // Reverse futility pruning margin.
+	Razoring        int // Razoring margin.
+}
+
+const (
+	// futilityDepth is the maximum depth at which quiet moves are futility pruned.
+	futilityDepth = 3
+	// reverseFutilityDepth is the maximum depth at which nodes are reverse futility pruned.
+	reverseFutilityDepth = 5
+	// razoringDepth is the maximum depth at which nodes are razored.
+	razoringDepth = 2
+)
+
+// staticEvaluation returns the static evaluation of the position and true
+// when forward pruning may apply to the node.
+//
+// Forward pruning does not apply at the root, in check, in searches with an
+// excluded move, or beyond the frontier depths.
+func staticEvaluation(input Input) (int, bool) {
+	if input.Margins == (Margins{}) || input.inCheck || input.ply == 0 ||
+		input.excluded != nil || input.Depth > reverseFutilityDepth {
+		return 0, false
+	}
+
+	return input.Evaluation.Evaluate(input.Position), true
+}
+
+// forwardPruning tries to prune the frontier node with reverse futility
+// pruning, then with razoring.
+//
+// Returns the output and true when the node is pruned. When it is not, the
+// output holds the number of nodes searched.
+func forwardPruning(ctx context.Context, input Input, eval int) (*Output, bool, error) {
+	if output, ok := reverseFutilityPruning(input, eval); ok {
+		return output, true, nil
+	}
+
+	return razoring(ctx, input, eval)
+}
+
+// reverseFutilityPruning tries to prune the node with its static evaluation:
+// if it exceeds beta by a margin growing with the depth, the current player
+// would most likely fail high anyway. It is also known as static null move
+// pruning.
+//
+// Returns the output and true when the node is pruned.
+func reverseFutilityPruning(input Input, eval int) (*Output, bool) {
+	if input.Margins.ReverseFutility == 0 || input.Depth > reverseFutilityDepth ||
+		isMateScore(input.beta) {
+		return nil, false
+	}
+
+	score := eval - input.Margins.ReverseFutility*input.Depth
+	if score < input.beta {
+		return nil, false
+	}
+
+	return &Output{
+		Depth: input.Depth,
+		Nodes: 1,
+		Score: score,
+	}, true
+}
+
+// razoring tries to prune the node with a quiescence search: if the static
+// evaluation is below alpha by a margin growing with the depth, the node is
+// searched by the quiescence search instead, and pruned if it fails low too.
+//
+// Returns the output and true when the node is pruned. When it is not, the
+// output holds the number of nodes searched.
+func razoring(ctx context.Context, input Input, eval int) (*Output, bool, error) {
+	result := &Output{}
+
+	if input.Margins.Razoring == 0 || input.Depth > razoringDepth ||
+		isMateScore(input.alpha) || eval+input.Margins.Razoring*input.Depth > input.alpha {
+		return result, false, nil
+	}
+
+	quiescent := input
+	quiescent.Depth = 0
+	output, _, err := leaf(ctx, quiescent)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result.Nodes = output.Nodes
+	if output.Score > input.alpha {
+		return result, false, nil
+	}
+
+	result.Depth = input.Depth
+	result.Score = output.Score
+	return result, true, nil
+}
+
+// futilityPruning reports whether the move can be skipped: at shallow depths,
+// a quiet move is futile when the static evaluation is below alpha by a margin
+// growing with the depth, as it is unlikely to raise the score that much.
+//
+// Killer moves are not pruned, nor are moves while no move has been found
+// that avoids being mated.
+func futilityPruning(input Input, move *chess.Move, eval, best int) bool {
+	if input.Margins.Futility == 0 || input.Depth > futilityDepth ||
+		isMateScore(input.alpha) || isMateScore(best) ||
+		!isQuiet(move) || isKiller(input, move) {
+		return false
+	}
+
+	return eval+input.Margins.Futility*input.Depth <= input.alpha
+}
@@ -0,0 +1,308 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+var testMargins = Margins{Futility: 100, ReverseFutility: 120, Razoring: 300}
+
+func TestStaticEvaluation(t *testing.T) {
+	pos := position("4k3/8/8/8/8/8/8/QQQ1K3 w - - 0 1")
+
+	type args struct {
+		margins  Margins
+		depth    int
+		ply      int
+		inCheck  bool
+		excluded string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"frontier", args{testMargins, 2, 1, false, ""}, true},
+		{"disabled", args{Margins{}, 2, 1, false, ""}, false},
+		{"root", args{testMargins, 2, 0, false, ""}, false},
+		{"in check", args{testMargins, 2, 1, true, ""}, false},
+		{"excluded search", args{testMargins, 2, 1, false, "e1d2"}, false},
+		{"deep", args{testMargins, reverseFutilityDepth + 1, 1, false, ""}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := Input{
+				Position:   pos,
+				Depth:      tt.args.depth,
+				Evaluation: evaluation.Pesto{},
+				Margins:    tt.args.margins,
+				ply:        tt.args.ply,
+				inCheck:    tt.args.inCheck,
+			}
+			if len(tt.args.excluded) > 0 {
+				input.excluded = validMove(pos, tt.args.excluded)
+			}
+
+			eval, ok := staticEvaluation(input)
+			assert.Equal(t, tt.want, ok)
+			if ok {
+				assert.Equal(t, evaluation.Pesto{}.Evaluate(pos), eval)
+			}
+		})
+	}
+}
+
+func TestReverseFutilityPruning(t *testing.T) {
+	type args struct {
+		margin int
+		depth  int
+		beta   int
+		eval   int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"pruned", args{120, 2, 0, 240}, true},
+		{"within margin", args{120, 2, 0, 239}, false},
+		{"disabled", args{0, 2, 0, 240}, false},
+		{"deep", args{120, reverseFutilityDepth + 1, 0, 10000}, false},
+		{"mate bound", args{120, 2, evaluation.Mate, 10000}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, ok := reverseFutilityPruning(Input{
+				Depth:   tt.args.depth,
+				alpha:   tt.args.beta - 1,
+				beta:    tt.args.beta,
+				Margins: Margins{ReverseFutility: tt.args.margin},
+			}, tt.args.eval)
+			assert.Equal(t, tt.want, ok)
+			if ok {
+				assert.GreaterOrEqual(t, output.Score, tt.args.beta)
+			}
+		})
+	}
+}
+
+func TestRazoring(t *testing.T) {
+	type args struct {
+		fen    string
+		margin int
+		depth  int
+		alpha  int
+		eval   int
+	}
+
+	const kings = "4k3/8/8/8/8/8/8/4K3 w - - 0 1"
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"pruned", args{kings, 300, 2, 500, -100}, true},
+		{"within margin", args{kings, 300, 2, 500, -99}, false},
+		{"disabled", args{kings, 0, 2, 500, -10000}, false},
+		{"deep", args{kings, 300, razoringDepth + 1, 500, -10000}, false},
+		{"mate bound", args{kings, 300, 2, -evaluation.Mate, -10000}, false},
+		{"quiescence above alpha", args{kings, 300, 2, -501, -10000}, false},
+		{"capture above alpha", args{"4k3/8/8/8/3q4/1p6/p7/3QK3 w - - 0 1", 300, 2, 500, -100}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, ok, err := razoring(context.Background(), Input{
+				Position:      position(tt.args.fen),
+				Depth:         tt.args.depth,
+				alpha:         tt.args.alpha,
+				beta:          tt.args.alpha + 1,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.AlphaBeta{},
+				Transposition: transposition.None{},
+				Margins:       Margins{Razoring: tt.args.margin},
+			}, tt.args.eval)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, ok)
+			if ok {
+				assert.LessOrEqual(t, output.Score, tt.args.alpha)
+			}
+		})
+	}
+}
+
+func TestFutilityPruning(t *testing.T) {
+	pos := position("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+
+	type args struct {
+		margin int
+		depth  int
+		alpha  int
+		move   string
+		killer bool
+		eval   int
+		best   int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"pruned", args{100, 2, 500, "a2a3", false, 300, 0}, true},
+		{"within margin", args{100, 2, 500, "a2a3", false, 301, 0}, false},
+		{"disabled", args{0, 2, 500, "a2a3", false, 0, 0}, false},
+		{"deep", args{100, futilityDepth + 1, 500, "a2a3", false, -10000, 0}, false},
+		{"mate bound", args{100, 2, evaluation.Mate - 1, "a2a3", false, 0, 0}, false},
+		{"mated", args{100, 2, 500, "a2a3", false, 0, -evaluation.Mate}, false},
+		{"capture", args{100, 2, 500, "d5e6", false, 0, 0}, false},
+		{"killer", args{100, 2, 500, "a2a3", true, 0, 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move := validMove(pos, tt.args.move)
+			input := Input{
+				Position: pos,
+				Depth:    tt.args.depth,
+				alpha:    tt.args.alpha,
+				beta:     tt.args.alpha + 1,
+				Margins:  Margins{Futility: tt.args.margin},
+				ply:      1,
+			}
+			if tt.args.killer {
+				input.Oracle = killers(input, move)
+			}
+
+			assert.Equal(t, tt.want, futilityPruning(input, move, tt.args.eval, tt.args.best))
+		})
+	}
+}
+
+func TestForwardPruning_Search(t *testing.T) {
+	type (
+		args struct {
+			fen     string
+			depth   int
+			alpha   int
+			beta    int
+			ply     int
+			margins Margins
+		}
+		want struct {
+			score int
+			nodes int
+			moves []string
+		}
+	)
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "reverse futility cutoff",
+			args: args{"4k3/8/8/8/8/8/8/QQQ1K3 w - - 0 1", 2, -1, 0, 1, Margins{ReverseFutility: 120}},
+			want: want{2646, 1, nil},
+		},
+		{
+			name: "razored",
+			args: args{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", 2, 500, 501, 1, Margins{Razoring: 100}},
+			want: want{0, 1, nil},
+		},
+		{
+			name: "futile quiet moves",
+			args: args{"4k3/8/8/8/8/8/8/R3K3 w - - 0 1", 1, 1000, 1001, 1, Margins{Futility: 100}},
+			want: want{523, 1, []string{"a1a8"}},
+		},
+		{
+			name: "futile quiet moves disabled",
+			args: args{"4k3/8/8/8/8/8/8/R3K3 w - - 0 1", 1, 1000, 1001, 1, Margins{}},
+			want: want{533, 15, []string{"e1e2"}},
+		},
+	}
+
+	searches := []struct {
+		name string
+		fn   func(context.Context, Input) (*Output, error)
+	}{
+		{"alphaBeta", alphaBeta},
+		{"pvs", pvs},
+	}
+
+	for _, s := range searches {
+		for _, tt := range tests {
+			t.Run(s.name+" "+tt.name, func(t *testing.T) {
+				output, err := s.fn(context.Background(), Input{
+					Position:      position(tt.args.fen),
+					Depth:         tt.args.depth,
+					alpha:         tt.args.alpha,
+					beta:          tt.args.beta,
+					Evaluation:    evaluation.Pesto{},
+					Oracle:        oracle.Order{},
+					Quiescence:    quiescence.None{},
+					Transposition: transposition.None{},
+					Margins:       tt.args.margins,
+					ply:           tt.args.ply,
+				})
+				assert.Nil(t, err)
+
+				var moves []string
+				for _, move := range output.PV {
+					moves = append(moves, move.String())
+				}
+				assert.Equal(t, tt.want.score, output.Score)
+				assert.Equal(t, tt.want.nodes, output.Nodes)
+				assert.Equal(t, tt.want.moves, moves)
+			})
+		}
+	}
+}
+
+func TestForwardPruning_Mate(t *testing.T) {
+	searches := []struct {
+		name string
+		fn   func(context.Context, Input) (*Output, error)
+	}{
+		{"alphaBeta", alphaBeta},
+		{"pvs", pvs},
+	}
+
+	for _, s := range searches {
+		t.Run(s.name, func(t *testing.T) {
+			output, err := s.fn(context.Background(), Input{
+				Position:      position("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1"),
+				Depth:         3,
+				alpha:         -evaluation.Mate,
+				beta:          evaluation.Mate,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+				Margins:       testMargins,
+			})
+			assert.Nil(t, err)
+
+			var moves []string
+			for _, move := range output.PV {
+				moves = append(moves, move.String())
+			}
+			assert.Equal(t, evaluation.Mate-3, output.Score)
+			assert.Equal(t, []string{"c6g2", "e2g2", "c1e1"}, moves)
+		})
+	}
+}
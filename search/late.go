@@ -0,0 +1,84 @@
+package search
+
+import (
+	"math"
+
+	"github.com/notnil/chess"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+)
+
+const (
+	// lateMoveReductionDepth is the minimum depth at which late moves are reduced.
+	lateMoveReductionDepth = 3
+	// lateMoveReductionIndex is the number of moves searched
+	// at full depth before late moves are reduced.
+	lateMoveReductionIndex = 3
+	// lateMovePruningDepth is the maximum depth at which late moves are pruned.
+	lateMovePruningDepth = 3
+	// lateMoveCount is the size of the move dimension of the reduction table.
+	lateMoveCount = 64
+)
+
+// lateMoveReductions holds the depth reductions indexed by depth and move index.
+var lateMoveReductions [maxDepth + 1][lateMoveCount]int
+
+func init() {
+	for depth := 1; depth <= maxDepth; depth++ {
+		for i := 1; i < lateMoveCount; i++ {
+			lateMoveReductions[depth][i] = int(0.75 + math.Log(float64(depth))*math.Log(float64(i))/2.25)
+		}
+	}
+}
+
+// lateMoveReduction returns the depth reduction of the i-th move of a node.
+//
+// Moves ordered late are less likely to be best, so they are searched
+// at a reduced depth that grows logarithmically with the depth and the
+// move index. Captures, promotions, checks and killer moves are never
+// reduced, nor are the moves of a player in check. The reduced search
+// is at least one ply deep.
+func lateMoveReduction(input Input, i int, move *chess.Move) int {
+	if !input.LateMoveReductions || input.inCheck ||
+		input.Depth < lateMoveReductionDepth || i < lateMoveReductionIndex ||
+		!isQuiet(move) || isKiller(input, move) {
+		return 0
+	}
+
+	depth := input.Depth
+	if depth > maxDepth {
+		depth = maxDepth
+	}
+	if i >= lateMoveCount {
+		i = lateMoveCount - 1
+	}
+
+	reduction := lateMoveReductions[depth][i]
+	if reduction > input.Depth-2 {
+		reduction = input.Depth - 2
+	}
+	return reduction
+}
+
+// lateMovePruning reports whether the i-th move of a node can be skipped.
+//
+// At shallow depths, quiet moves ordered after a number of moves growing
+// with the depth are pruned. Killer moves are not pruned, nor are moves
+// at the root, in check, or while no move has been found that avoids
+// being mated.
+func lateMovePruning(input Input, i int, move *chess.Move, best int) bool {
+	if !input.LateMoveReductions || input.inCheck || input.ply == 0 ||
+		input.Depth > lateMovePruningDepth || best <= -evaluation.Mate+maxDepth ||
+		!isQuiet(move) || isKiller(input, move) {
+		return false
+	}
+
+	return i >= 3+input.Depth*input.Depth
+}
+
+// isQuiet reports whether the move is neither a capture,
+// a promotion nor a check.
+func isQuiet(move *chess.Move) bool {
+	return !move.HasTag(chess.Capture) && !move.HasTag(chess.EnPassant) &&
+		!move.HasTag(chess.Check) && move.Promo() == chess.NoPieceType
+}
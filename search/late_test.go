@@ -0,0 +1,267 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notnil/chess"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+func TestLateMoveReductionsTable(t *testing.T) {
+	assert.Equal(t, 0, lateMoveReductions[1][lateMoveCount-1])
+	assert.Equal(t, 0, lateMoveReductions[maxDepth][1])
+	assert.Equal(t, 1, lateMoveReductions[3][3])
+	assert.Equal(t, 3, lateMoveReductions[10][20])
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		for i := 2; i < lateMoveCount; i++ {
+			assert.GreaterOrEqual(t, lateMoveReductions[depth][i], lateMoveReductions[depth][i-1])
+			assert.GreaterOrEqual(t, lateMoveReductions[depth][i], lateMoveReductions[depth-1][i])
+		}
+	}
+}
+
+func TestIsQuiet(t *testing.T) {
+	tests := []struct {
+		fen  string
+		move string
+		want bool
+	}{
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "a2a3", true},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "d5e6", false},
+		{"2r3k1/1q1nbppp/r3p3/3pP3/pPpP4/P1Q2N2/2RN1PPP/2R4K b - b3 0 23", "a4b3", false},
+		{"4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", false},
+		{"8/4P3/8/8/8/8/8/k3K3 w - - 0 1", "e7e8q", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			assert.Equal(t, tt.want, isQuiet(validMove(position(tt.fen), tt.move)))
+		})
+	}
+}
+
+func TestLateMoveReduction(t *testing.T) {
+	type args struct {
+		depth              int
+		lateMoveReductions bool
+		inCheck            bool
+		i                  int
+		move               string
+		killer             bool
+	}
+
+	pos := position("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{"reduced", args{10, true, false, 20, "a2a3", false}, 3},
+		{"beyond table", args{10, true, false, 200, "a2a3", false}, 4},
+		{"disabled", args{10, false, false, 20, "a2a3", false}, 0},
+		{"in check", args{10, true, true, 20, "a2a3", false}, 0},
+		{"shallow", args{lateMoveReductionDepth - 1, true, false, 20, "a2a3", false}, 0},
+		{"early move", args{10, true, false, lateMoveReductionIndex - 1, "a2a3", false}, 0},
+		{"capture", args{10, true, false, 20, "d5e6", false}, 0},
+		{"killer", args{10, true, false, 20, "a2a3", true}, 0},
+		{"clamped", args{3, true, false, 60, "a2a3", false}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move := validMove(pos, tt.args.move)
+			input := Input{
+				Position:           pos,
+				Depth:              tt.args.depth,
+				Oracle:             oracle.Order{},
+				LateMoveReductions: tt.args.lateMoveReductions,
+				ply:                1,
+				inCheck:            tt.args.inCheck,
+			}
+			if tt.args.killer {
+				input.Oracle = killers(input, move)
+			}
+
+			assert.Equal(t, tt.want, lateMoveReduction(input, tt.args.i, move))
+		})
+	}
+}
+
+func TestLateMoveReduction_Root(t *testing.T) {
+	type args struct {
+		fen  string
+		move string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"root", args{"4k3/8/8/8/8/8/r7/4K3 w - - 0 1", "e1d1"}, true},
+		{"root in check", args{"4k3/8/8/8/8/8/4r3/4K3 w - - 0 1", "e1d1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := position(tt.args.fen)
+			root := Input{
+				Position:           pos,
+				Oracle:             oracle.Order{},
+				LateMoveReductions: true,
+			}.root(10, -evaluation.Mate, evaluation.Mate)
+
+			assert.Equal(t, !tt.want, root.inCheck)
+			assert.Equal(t, tt.want, lateMoveReduction(root, 20, validMove(pos, tt.args.move)) > 0)
+		})
+	}
+}
+
+func TestLateMovePruning(t *testing.T) {
+	type args struct {
+		depth              int
+		lateMoveReductions bool
+		ply                int
+		inCheck            bool
+		i                  int
+		move               string
+		killer             bool
+		best               int
+	}
+
+	pos := position("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"pruned", args{2, true, 1, false, 7, "a2a3", false, 0}, true},
+		{"early move", args{2, true, 1, false, 6, "a2a3", false, 0}, false},
+		{"disabled", args{2, false, 1, false, 7, "a2a3", false, 0}, false},
+		{"root", args{2, true, 0, false, 7, "a2a3", false, 0}, false},
+		{"in check", args{2, true, 1, true, 7, "a2a3", false, 0}, false},
+		{"deep", args{lateMovePruningDepth + 1, true, 1, false, 30, "a2a3", false, 0}, false},
+		{"mated", args{2, true, 1, false, 7, "a2a3", false, -evaluation.Mate}, false},
+		{"capture", args{2, true, 1, false, 7, "d5e6", false, 0}, false},
+		{"killer", args{2, true, 1, false, 7, "a2a3", true, 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move := validMove(pos, tt.args.move)
+			input := Input{
+				Position:           pos,
+				Depth:              tt.args.depth,
+				Oracle:             oracle.Order{},
+				LateMoveReductions: tt.args.lateMoveReductions,
+				ply:                tt.args.ply,
+				inCheck:            tt.args.inCheck,
+			}
+			if tt.args.killer {
+				input.Oracle = killers(input, move)
+			}
+
+			assert.Equal(t, tt.want, lateMovePruning(input, tt.args.i, move, tt.args.best))
+		})
+	}
+}
+
+func TestLateMoveReductions_Search(t *testing.T) {
+	type (
+		args struct {
+			fen   string
+			depth int
+			move  string // late quiet move that must be searched again
+		}
+		want struct {
+			score int
+			moves []string
+		}
+	)
+
+	tests := []struct {
+		name string
+		fn   func(context.Context, Input) (*Output, error)
+		args args
+		want want
+	}{
+		{
+			name: "alphaBeta quiet mate in 2",
+			fn:   alphaBeta,
+			args: args{"kbK5/pp6/1P6/8/8/8/8/R7 w - - 0 1", 5, "a1a6"},
+			want: want{evaluation.Mate - 3, []string{"a1a6", "b7a6", "b6b7"}},
+		},
+		{
+			name: "pvs quiet mate in 2",
+			fn:   pvs,
+			args: args{"kbK5/pp6/1P6/8/8/8/8/R7 w - - 0 1", 5, "a1a6"},
+			want: want{evaluation.Mate - 3, []string{"a1a6", "b7a6", "b6b7"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := Input{
+				Position:           position(tt.args.fen),
+				Depth:              tt.args.depth,
+				alpha:              -evaluation.Mate,
+				beta:               evaluation.Mate,
+				Evaluation:         evaluation.Pesto{},
+				Oracle:             oracle.Order{},
+				Quiescence:         quiescence.None{},
+				Transposition:      transposition.None{},
+				LateMoveReductions: true,
+			}
+
+			// the key move is ordered late enough to be reduced
+			picker := newMovePicker(input, nil)
+			for i := 0; ; i++ {
+				move, ok := picker.next()
+				if !assert.True(t, ok) {
+					return
+				}
+				if move.String() == tt.args.move {
+					assert.Greater(t, lateMoveReduction(input, i, move), 0)
+					break
+				}
+			}
+
+			output, err := tt.fn(context.Background(), input)
+			assert.Nil(t, err)
+
+			var moves []string
+			for _, move := range output.PV {
+				moves = append(moves, move.String())
+			}
+			assert.Equal(t, tt.want.score, output.Score)
+			assert.Equal(t, tt.want.moves, moves)
+		})
+	}
+}
+
+// killers returns an oracle for which the move is a killer move at the ply of the input.
+func killers(input Input, move *chess.Move) oracle.Interface {
+	kh := &oracle.KillerHistory{}
+	kh.Cutoff(oracle.Node{Position: input.Position, Ply: input.ply, Depth: 1}, move)
+	return kh
+}
+
+// validMove returns the valid move of the position in UCI notation,
+// with its tags set.
+func validMove(pos *chess.Position, uci string) *chess.Move {
+	for _, move := range pos.ValidMoves() {
+		if move.String() == uci {
+			return move
+		}
+	}
+	return nil
+}
@@ -31,22 +31,22 @@ func TestNegamax(t *testing.T) {
 		{
 			name: "checkmate",
 			args: args{"8/8/8/5K1k/8/8/8/7R b - - 0 1", 1},
-			want: want{Output{0, 1, -evaluation.Mate, 0, nil}, nil, nil},
+			want: want{Output{0, 1, -evaluation.Mate, 0, Exact, nil, nil}, nil, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"8/8/8/5K1k/8/8/8/5R2 w - - 0 1", 1},
-			want: want{Output{1, 15, evaluation.Mate - 1, 0, nil}, []string{"f1h1"}, nil},
+			want: want{Output{1, 15, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f1h1"}, nil},
 		},
 		{
 			name: "mate in 1",
 			args: args{"r1b1kb1r/pppp1ppp/2n1pq2/8/3Pn2N/2P3P1/PP1NPP1P/R1BQKB1R b KQkq - 3 6", 1},
-			want: want{Output{1, 46, evaluation.Mate - 1, 0, nil}, []string{"f6f2"}, nil},
+			want: want{Output{1, 46, evaluation.Mate - 1, 0, Exact, nil, nil}, []string{"f6f2"}, nil},
 		},
 		{
 			name: "mate in 2",
 			args: args{"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3},
-			want: want{Output{3, 90094, evaluation.Mate - 3, 0, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
+			want: want{Output{3, 90094, evaluation.Mate - 3, 0, Exact, nil, nil}, []string{"c6g2", "e2g2", "c1e1"}, nil},
 		},
 	}
 
@@ -0,0 +1,177 @@
+package search
+
+import (
+	"context"
+
+	"github.com/notnil/chess"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+)
+
+const (
+	// nullMoveDepth is the minimum depth at which null move pruning is tried.
+	nullMoveDepth = 3
+	// nullMoveVerificationDepth is the minimum depth at which a null move
+	// cutoff is verified by a reduced search.
+	nullMoveVerificationDepth = 6
+)
+
+// binary position flags of the notnil/chess package,
+// in the last byte of the binary format
+const (
+	binaryTurn      byte = 1 << 4
+	binaryEnPassant byte = 1 << 5
+)
+
+// nullMovePruning tries to prune the node with a null move: if passing the
+// turn still fails high on a reduced search, a real move would most likely
+// fail high too.
+//
+// The null move is not tried at the root, in check, after another null move,
+// when the current player only has pawns left as zugzwang is likely, when
+// the static evaluation is below beta, or when the null move position cannot
+// be built. At high depths, the cutoff can be verified by a reduced search of
+// the position without null move, which guards against zugzwang at the cost
+// of the nodes of that search.
+//
+// Returns the output and true when the node is pruned. When it is not, the
+// output holds the number of nodes searched.
+func nullMovePruning(ctx context.Context, input Input, search func(context.Context, Input) (*Output, error)) (*Output, bool, error) {
+	result := &Output{}
+
+	if !input.NullMove || input.skipNull || input.inCheck || input.ply == 0 ||
+		input.Depth < nullMoveDepth || isMateScore(input.beta) {
+		return result, false, nil
+	}
+
+	eval := input.Evaluation.Evaluate(input.Position)
+	if eval < input.beta || !hasPieces(input.Position) {
+		return result, false, nil
+	}
+
+	reduction := nullMoveReduction(input.Depth, eval-input.beta)
+	depth := input.Depth - 1 - reduction
+	if depth < 0 {
+		depth = 0
+	}
+
+	position, err := nullMove(input.Position)
+	if err != nil {
+		return result, false, nil
+	}
+
+	current, err := search(ctx, Input{
+		Position:           position,
+		Depth:              depth,
+		alpha:              -input.beta,
+		beta:               -input.beta + 1,
+		Evaluation:         input.Evaluation,
+		Oracle:             input.Oracle,
+		Quiescence:         input.Quiescence,
+		Transposition:      input.Transposition,
+		NullMove:           input.NullMove,
+		NullMoveVerify:     input.NullMoveVerify,
+		LateMoveReductions: input.LateMoveReductions,
+		Extensions:         input.Extensions,
+		ExtensionBudget:    input.ExtensionBudget,
+		Margins:            input.Margins,
+		ply:                input.ply + 1,
+		extended:           input.extended,
+		skipNull:           true,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	result.Nodes += current.Nodes
+	score := -current.Score
+	if score < input.beta {
+		return result, false, nil
+	}
+
+	// mates found after passing the turn are not proven
+	if isMateScore(score) {
+		score = input.beta
+	}
+
+	if input.NullMoveVerify && input.Depth >= nullMoveVerificationDepth {
+		verification, err := search(ctx, Input{
+			Position:           input.Position,
+			Depth:              depth,
+			alpha:              input.beta - 1,
+			beta:               input.beta,
+			Evaluation:         input.Evaluation,
+			Oracle:             input.Oracle,
+			Quiescence:         input.Quiescence,
+			Transposition:      input.Transposition,
+			NullMove:           input.NullMove,
+			NullMoveVerify:     input.NullMoveVerify,
+			LateMoveReductions: input.LateMoveReductions,
+			Extensions:         input.Extensions,
+			ExtensionBudget:    input.ExtensionBudget,
+			Margins:            input.Margins,
+			ply:                input.ply,
+			extended:           input.extended,
+			skipNull:           true,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		result.Nodes += verification.Nodes
+		if verification.Score < input.beta {
+			return result, false, nil
+		}
+	}
+
+	result.Score = score
+	return result, true, nil
+}
+
+// nullMoveReduction returns the depth reduction of the null move search.
+//
+// The reduction grows with the depth and with the margin of the static
+// evaluation over beta, as large margins are less likely to be wrong.
+func nullMoveReduction(depth, margin int) int {
+	bonus := margin / 200
+	if bonus > 3 {
+		bonus = 3
+	}
+	return 3 + depth/4 + bonus
+}
+
+// nullMove returns the position after the current player passes the turn.
+//
+// The position is copied through its binary format, which is much faster
+// than through its FEN, with the turn flipped and the en passant square cleared.
+func nullMove(position *chess.Position) (*chess.Position, error) {
+	data, err := position.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data[len(data)-1] ^= binaryTurn
+	data[len(data)-1] &^= binaryEnPassant
+
+	next := &chess.Position{}
+	if err := next.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// hasPieces reports whether the current player has
+// pieces other than the king and pawns.
+func hasPieces(position *chess.Position) bool {
+	for _, p := range position.Board().SquareMap() {
+		if p.Color() == position.Turn() && p.Type() != chess.King && p.Type() != chess.Pawn {
+			return true
+		}
+	}
+	return false
+}
+
+// isMateScore reports whether the score is a mate score.
+func isMateScore(score int) bool {
+	return score >= evaluation.Mate-maxDepth || score <= -evaluation.Mate+maxDepth
+}
@@ -0,0 +1,218 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+func TestNullMove(t *testing.T) {
+	tests := []struct {
+		fen  string
+		want string
+	}{
+		{
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 1",
+		},
+		{
+			"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+			"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R b KQkq - 0 1",
+		},
+		{
+			"4k3/8/8/8/8/8/8/4K2R b K - 12 40",
+			"4k3/8/8/8/8/8/8/4K2R w K - 12 40",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen, func(t *testing.T) {
+			got, err := nullMove(position(tt.fen))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestHasPieces(t *testing.T) {
+	tests := []struct {
+		fen  string
+		want bool
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", true},
+		{"4k3/pppp4/8/8/8/8/PPPP4/4K1N1 w - - 0 1", true},
+		{"4k3/pppp4/8/8/8/8/PPPP4/4K1N1 b - - 0 1", false},
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasPieces(position(tt.fen)))
+		})
+	}
+}
+
+func TestNullMoveReduction(t *testing.T) {
+	assert.Equal(t, 3, nullMoveReduction(3, 0))
+	assert.Equal(t, 5, nullMoveReduction(8, 199))
+	assert.Equal(t, 6, nullMoveReduction(8, 200))
+	assert.Equal(t, 8, nullMoveReduction(8, 10000))
+}
+
+func TestNullMovePruning(t *testing.T) {
+	type (
+		args struct {
+			fen         string
+			depth       int
+			alpha, beta int
+			ply         int
+			nullMove    bool
+		}
+		want struct {
+			score int
+			moves []string
+		}
+	)
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "cutoff",
+			args: args{"4k3/pppp4/8/8/8/8/PPPP4/R3K3 w - - 0 1", 4, -100, -99, 1, true},
+			want: want{499, nil},
+		},
+		{
+			name: "disabled",
+			args: args{"4k3/pppp4/8/8/8/8/PPPP4/R3K3 w - - 0 1", 4, -100, -99, 1, false},
+			want: want{463, []string{"e1d1", "e8e7", "d1c1", "e7e6"}},
+		},
+		{
+			name: "mate in 2",
+			args: args{"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1", 3, -evaluation.Mate, evaluation.Mate, 0, true},
+			want: want{evaluation.Mate - 3, []string{"c6g2", "e2g2", "c1e1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := alphaBeta(context.Background(), Input{
+				Position:      position(tt.args.fen),
+				Depth:         tt.args.depth,
+				alpha:         tt.args.alpha,
+				beta:          tt.args.beta,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+				NullMove:      tt.args.nullMove,
+				ply:           tt.args.ply,
+			})
+			assert.Nil(t, err)
+
+			var moves []string
+			for _, move := range output.PV {
+				moves = append(moves, move.String())
+			}
+			assert.Equal(t, tt.want.score, output.Score)
+			assert.Equal(t, tt.want.moves, moves)
+		})
+	}
+}
+
+func TestNullMovePruning_Conditions(t *testing.T) {
+	type args struct {
+		fen               string
+		depth             int
+		alpha, beta       int
+		ply               int
+		nullMove          bool
+		inCheck, skipNull bool
+	}
+
+	const fen = "4k3/pppp4/8/8/8/8/PPPP4/R3K3 w - - 0 1"
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"pruned", args{fen, 4, -100, -99, 1, true, false, false}, true},
+		{"disabled", args{fen, 4, -100, -99, 1, false, false, false}, false},
+		{"root", args{fen, 4, -100, -99, 0, true, false, false}, false},
+		{"in check", args{fen, 4, -100, -99, 1, true, true, false}, false},
+		{"after null move", args{fen, 4, -100, -99, 1, true, false, true}, false},
+		{"shallow", args{fen, nullMoveDepth - 1, -100, -99, 1, true, false, false}, false},
+		{"below beta", args{fen, 4, 9999, 10000, 1, true, false, false}, false},
+		{"mate bound", args{fen, 4, -evaluation.Mate, evaluation.Mate, 1, true, false, false}, false},
+		{"pawns only", args{"4k3/pppp4/8/8/8/8/PPPP4/4K3 w - - 0 1", 4, -100, -99, 1, true, false, false}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, ok, err := nullMovePruning(context.Background(), Input{
+				Position:      position(tt.args.fen),
+				Depth:         tt.args.depth,
+				alpha:         tt.args.alpha,
+				beta:          tt.args.beta,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+				NullMove:      tt.args.nullMove,
+				ply:           tt.args.ply,
+				inCheck:       tt.args.inCheck,
+				skipNull:      tt.args.skipNull,
+			}, alphaBeta)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, ok)
+			if ok {
+				assert.GreaterOrEqual(t, output.Score, tt.args.beta)
+			}
+		})
+	}
+}
+
+func TestNullMovePruning_Verification(t *testing.T) {
+	// the score fails high after the null move, as it is negated,
+	// and fails low in the verification search
+	search := func(context.Context, Input) (*Output, error) {
+		return &Output{Nodes: 1, Score: -1000}, nil
+	}
+
+	tests := []struct {
+		name   string
+		verify bool
+		depth  int
+		want   bool
+	}{
+		{"verified", true, nullMoveVerificationDepth, false},
+		{"not verified", false, nullMoveVerificationDepth, true},
+		{"shallow", true, nullMoveVerificationDepth - 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok, err := nullMovePruning(context.Background(), Input{
+				Position:       position("4k3/pppp4/8/8/8/8/PPPP4/R3K3 w - - 0 1"),
+				Depth:          tt.depth,
+				alpha:          -100,
+				beta:           -99,
+				Evaluation:     evaluation.Pesto{},
+				NullMove:       true,
+				NullMoveVerify: tt.verify,
+				ply:            1,
+			}, search)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package search
+
+import "github.com/notnil/chess"
+
+// movePicker returns the moves of a node one at a time.
+//
+// The hash move of the transposition table is returned first, before the
+// other moves are filtered and ordered: if it causes a cutoff, the moves
+// of the node are never ordered.
+type movePicker struct {
+	input      Input
+	hashMove   *chess.Move
+	hashPicked bool
+	moves      []*chess.Move
+	generated  bool
+}
+
+// newMovePicker returns a move picker for the node.
+//
+// The hash move is ignored if it is not one of the moves to search: the entry
+// may have been stored for another position with the same key, or the search
+// may be restricted to other moves.
+func newMovePicker(input Input, hashMove *chess.Move) *movePicker {
+	if hashMove != nil {
+		hashMove = findMove(searchMoves(input), hashMove)
+	}
+
+	return &movePicker{input: input, hashMove: hashMove}
+}
+
+// next returns the next move, and false when there are no more moves.
+func (mp *movePicker) next() (*chess.Move, bool) {
+	if mp.hashMove != nil && !mp.hashPicked {
+		mp.hashPicked = true
+		return mp.hashMove, true
+	}
+
+	if !mp.generated {
+		mp.generate()
+	}
+
+	if len(mp.moves) == 0 {
+		return nil, false
+	}
+
+	move := mp.moves[0]
+	mp.moves = mp.moves[1:]
+	return move, true
+}
+
+// generate generates and orders the moves,
+// without the hash move and the excluded move.
+func (mp *movePicker) generate() {
+	mp.generated = true
+
+	moves := searchMoves(mp.input)
+	if mp.hashMove != nil || mp.input.excluded != nil {
+		filtered := make([]*chess.Move, 0, len(moves))
+		for _, move := range moves {
+			if !mp.skip(move) {
+				filtered = append(filtered, move)
+			}
+		}
+		moves = filtered
+	}
+
+	orderMoves(mp.input, moves)
+	mp.moves = moves
+}
+
+// skip reports whether the generated move is the hash move or the excluded move.
+func (mp *movePicker) skip(move *chess.Move) bool {
+	return (mp.hashMove != nil && sameMove(move, mp.hashMove)) ||
+		(mp.input.excluded != nil && sameMove(move, mp.input.excluded))
+}
+
+// containsMove reports whether the move is in the list.
+func containsMove(moves []*chess.Move, move *chess.Move) bool {
+	for _, m := range moves {
+		if sameMove(m, move) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMove returns the move of the list that is the same as the move,
+// or nil if there is none.
+func findMove(moves []*chess.Move, move *chess.Move) *chess.Move {
+	for _, m := range moves {
+		if sameMove(m, move) {
+			return m
+		}
+	}
+	return nil
+}
+
+// sameMove reports whether both moves have the same squares and promotion.
+func sameMove(a, b *chess.Move) bool {
+	return a.S1() == b.S1() && a.S2() == b.S2() && a.Promo() == b.Promo()
+}
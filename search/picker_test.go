@@ -0,0 +1,203 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notnil/chess"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+func TestMovePicker(t *testing.T) {
+	pos := position("7k/P7/8/8/8/8/8/K7 w - - 0 1")
+
+	tests := []struct {
+		name        string
+		searchMoves []string
+		hashMove    string
+		want        []string
+	}{
+		{"no hash move", nil, "", []string{"a7a8q", "a7a8n", "a1b1", "a1a2", "a1b2", "a7a8r", "a7a8b"}},
+		{"hash move", nil, "a1b2", []string{"a1b2", "a7a8q", "a7a8n", "a1b1", "a1a2", "a7a8r", "a7a8b"}},
+		{"search moves", []string{"a1b1", "a1b2"}, "a1b2", []string{"a1b2", "a1b1"}},
+		{"hash move not in search moves", []string{"a1b1", "a1a2"}, "a1b2", []string{"a1b1", "a1a2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := Input{Position: pos, Oracle: oracle.Order{}}
+			for _, move := range tt.searchMoves {
+				input.SearchMoves = append(input.SearchMoves, validMove(pos, move))
+			}
+
+			var hashMove *chess.Move
+			if tt.hashMove != "" {
+				hashMove = validMove(pos, tt.hashMove)
+			}
+
+			picker := newMovePicker(input, hashMove)
+			var got []string
+			for move, ok := picker.next(); ok; move, ok = picker.next() {
+				got = append(got, move.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMovePicker_Lazy(t *testing.T) {
+	pos := position("7k/P7/8/8/8/8/8/K7 w - - 0 1")
+	picker := newMovePicker(Input{Position: pos, Oracle: oracle.Order{}}, validMove(pos, "a1b2"))
+
+	move, ok := picker.next()
+	assert.True(t, ok)
+	assert.Equal(t, "a1b2", move.String())
+	assert.False(t, picker.generated)
+}
+
+func TestMovePicker_IllegalHashMove(t *testing.T) {
+	pos := position("7k/P7/8/8/8/8/8/K7 w - - 0 1")
+	// hash move of another position stored under the same key
+	hashMove := validMove(position("7k/8/8/8/8/8/8/1K6 w - - 0 1"), "b1c2")
+
+	picker := newMovePicker(Input{Position: pos, Oracle: oracle.Order{}}, hashMove)
+	var got []string
+	for move, ok := picker.next(); ok; move, ok = picker.next() {
+		got = append(got, move.String())
+	}
+	assert.Equal(t, []string{"a7a8q", "a7a8n", "a1b1", "a1a2", "a1b2", "a7a8r", "a7a8b"}, got)
+}
+
+func TestTranspositionPV(t *testing.T) {
+	table := mapTransposition{}
+	pos := position("7k/8/8/8/8/8/8/K7 w - - 0 1")
+	line := []string{"a1b1", "h8g8", "b1a1", "g8h8"}
+	for current, i := pos, 0; i < len(line); i++ {
+		move := validMove(current, line[i])
+		table.Set(current, transposition.Entry{Move: move})
+		current = current.Update(move)
+	}
+
+	tests := []struct {
+		name  string
+		depth int
+		want  []string
+	}{
+		{"depth", 2, []string{"a1b1", "h8g8"}},
+		{"repetition", 10, []string{"a1b1", "h8g8", "b1a1", "g8h8"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, move := range transpositionPV(table, pos, tt.depth) {
+				got = append(got, move.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTranspositionPV_IllegalMove(t *testing.T) {
+	table := mapTransposition{}
+	pos := position("7k/8/8/8/8/8/8/K7 w - - 0 1")
+	move := validMove(pos, "a1b1")
+	table.Set(pos, transposition.Entry{Move: move})
+	// best move of another position stored under the same key
+	other := position("7k/8/8/8/8/8/8/1K6 w - - 0 1")
+	table.Set(pos.Update(move), transposition.Entry{Move: validMove(other, "b1c2")})
+
+	assert.Equal(t, []*chess.Move{move}, transpositionPV(table, pos, 10))
+}
+
+func TestProbeTransposition(t *testing.T) {
+	pos := position("7k/P7/8/8/8/8/8/K7 w - - 0 1")
+	move := validMove(pos, "a7a8q")
+	table := mapTransposition{}
+	table.Set(pos, transposition.Entry{Score: 500, Depth: 2, Flag: transposition.Exact, Move: move})
+
+	input := Input{Position: pos, Depth: 3, Transposition: table}
+	output, hashMove, ok := probeTransposition(&input)
+	assert.False(t, ok)
+	assert.Nil(t, output)
+	assert.Equal(t, move, hashMove)
+
+	input.Depth = 2
+	output, _, ok = probeTransposition(&input)
+	assert.True(t, ok)
+	assert.Equal(t, 500, output.Score)
+	assert.Equal(t, []*chess.Move{move}, output.PV)
+
+	input.SearchMoves = []*chess.Move{validMove(pos, "a1b1")}
+	_, hashMove, ok = probeTransposition(&input)
+	assert.False(t, ok)
+	assert.Equal(t, move, hashMove)
+}
+
+func TestAlphaBetaWithHashMove(t *testing.T) {
+	for _, fen := range testPVSPositions {
+		t.Run(fen, func(t *testing.T) {
+			input := Input{
+				Position:      position(fen),
+				Depth:         3,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+			}
+
+			want := searchOutputs(AlphaBeta{}, input)
+
+			table := mapTransposition{}
+			input.Transposition = table
+			got := searchOutputs(AlphaBeta{}, input)
+
+			last := got[len(got)-1]
+			assert.Equal(t, want[len(want)-1].Score, last.Score)
+			assert.NotEmpty(t, last.PV)
+
+			entry, cached := table.Get(input.Position)
+			assert.True(t, cached)
+			assert.Equal(t, last.PV[0], entry.Move)
+		})
+	}
+}
+
+// searchOutputs runs the search strategy and returns all the outputs.
+func searchOutputs(strategy Interface, input Input) []*Output {
+	output := make(chan *Output)
+	go func() {
+		defer close(output)
+		strategy.Search(context.Background(), input, output)
+	}()
+
+	var outputs []*Output
+	for o := range output {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+// mapTransposition is a transposition table backed by a map,
+// which unlike Ristretto stores every entry synchronously.
+type mapTransposition map[[16]byte]transposition.Entry
+
+func (mapTransposition) String() string { return "Map" }
+
+func (mapTransposition) Init(size int) error { return nil }
+
+func (m mapTransposition) Set(key *chess.Position, entry transposition.Entry) {
+	m[key.Hash()] = entry
+}
+
+func (m mapTransposition) Get(key *chess.Position) (transposition.Entry, bool) {
+	entry, ok := m[key.Hash()]
+	return entry, ok
+}
+
+func (mapTransposition) Close() {}
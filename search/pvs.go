@@ -0,0 +1,217 @@
+package search
+
+import (
+	"context"
+
+	"github.com/notnil/chess"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+)
+
+const (
+	// aspirationDepth is the depth from which aspiration windows are used.
+	aspirationDepth = 4
+	// aspirationWindow is the initial half-width of the aspiration window.
+	aspirationWindow = 25
+	// aspirationMaxWindow is the half-width above which the search
+	// falls back to a full window.
+	aspirationMaxWindow = 1000
+)
+
+// PVS implements the Principal Variation Search, a variant of alpha beta.
+//
+// The first move of each node is searched with a full window, the others
+// with a zero window that only proves they are worse. A move failing high
+// on the zero window is searched again with a full window.
+//
+// Each iteration uses an aspiration window around the score of the previous
+// iteration. When the score falls outside the window, the bound is reported
+// and the window is widened on that side until the score falls inside.
+type PVS struct{}
+
+// String implements the Interface interface.
+func (PVS) String() string {
+	return "PVS"
+}
+
+// Search implements the Interface interface.
+func (PVS) Search(ctx context.Context, input Input, output chan<- *Output) {
+	var score int
+	for depth := 1; depth <= input.Depth; depth++ {
+		delta := aspirationWindow
+		alpha, beta := -evaluation.Mate, evaluation.Mate
+		if depth >= aspirationDepth && mateIn(score) == 0 {
+			alpha, beta = score-delta, score+delta
+		}
+
+		for {
+			o, err := pvs(ctx, input.root(depth, alpha, beta))
+			if err != nil {
+				return
+			}
+			o.Mate = mateIn(o.Score)
+
+			delta *= 2
+			switch {
+			case o.Score <= alpha && alpha > -evaluation.Mate:
+				// the moves of a fail low are not reliable
+				o.Bound, o.PV = UpperBound, nil
+				alpha = widenAlpha(score, delta)
+			case o.Score >= beta && beta < evaluation.Mate:
+				o.Bound = LowerBound
+				beta = widenBeta(score, delta)
+			}
+
+			output <- o
+
+			if o.Bound == Exact {
+				score = o.Score
+				break
+			}
+		}
+	}
+}
+
+// widenAlpha returns the lower bound of an aspiration window.
+func widenAlpha(score, delta int) int {
+	if delta > aspirationMaxWindow {
+		return -evaluation.Mate
+	}
+	return score - delta
+}
+
+// widenBeta returns the upper bound of an aspiration window.
+func widenBeta(score, delta int) int {
+	if delta > aspirationMaxWindow {
+		return evaluation.Mate
+	}
+	return score + delta
+}
+
+// pvs is the recursive function that implements the Principal Variation Search.
+func pvs(ctx context.Context, input Input) (*Output, error) {
+	select {
+	case <-ctx.Done():
+		return nil, context.Canceled
+	default:
+	}
+
+	alphaOriginal := input.alpha
+
+	output, hashMove, ok := probeTransposition(&input)
+	if ok {
+		return output, nil
+	}
+
+	if output, ok, err := leaf(ctx, input); ok || err != nil {
+		return output, err
+	}
+
+	// forward and null move pruning are only tried in zero window nodes
+	pruning, null := &Output{}, &Output{}
+	var eval int
+	var frontier bool
+	if input.beta-input.alpha == 1 {
+		var ok bool
+		var err error
+		if eval, frontier = staticEvaluation(input); frontier {
+			pruning, ok, err = forwardPruning(ctx, input, eval)
+			if ok || err != nil {
+				return pruning, err
+			}
+		}
+
+		null, ok, err = nullMovePruning(ctx, input, pvs)
+		if ok || err != nil {
+			return null, err
+		}
+	}
+
+	singular, singularNodes, err := singularExtension(ctx, input, hashMove, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Output{
+		Depth: input.Depth,
+		Nodes: pruning.Nodes + null.Nodes + singularNodes,
+		Score: -evaluation.Mate,
+	}
+
+	picker := newMovePicker(input, hashMove)
+	for i := 0; ; i++ {
+		move, ok := picker.next()
+		if !ok {
+			break
+		}
+
+		// extended moves are neither pruned nor reduced,
+		// late and futile moves are only pruned in zero window nodes
+		plies := extension(input, move, singular && sameMove(move, hashMove))
+		if plies == 0 && input.beta-input.alpha == 1 && (lateMovePruning(input, i, move, result.Score) ||
+			frontier && futilityPruning(input, move, eval, result.Score)) {
+			continue
+		}
+
+		var current *Output
+		var nodes int
+
+		if i > 0 {
+			reduction := 0
+			if plies == 0 {
+				reduction = lateMoveReduction(input, i, move)
+			}
+
+			scout, err := pvs(ctx, input.scoutChild(move, reduction).extend(plies))
+			if err != nil {
+				return nil, err
+			}
+			nodes = scout.Nodes
+
+			// a reduced move failing high is searched again at full depth
+			if reduction > 0 && -scout.Score > input.alpha {
+				scout, err = pvs(ctx, input.scoutChild(move, 0))
+				if err != nil {
+					return nil, err
+				}
+				nodes += scout.Nodes
+			}
+
+			// the scout proved the move is not better, or it fails high anyway
+			if score := -scout.Score; score <= input.alpha || score >= input.beta {
+				scout.Nodes = nodes
+				current = scout
+			}
+		}
+
+		if current == nil {
+			full, err := pvs(ctx, input.child(move, -input.beta, -input.alpha).extend(plies))
+			if err != nil {
+				return nil, err
+			}
+			full.Nodes += nodes
+			current = full
+		}
+
+		current.Score = -current.Score
+		if current.Score > result.Score {
+			result.Score = current.Score
+			result.PV = append([]*chess.Move{move}, current.PV...)
+		}
+		result.Nodes += current.Nodes
+
+		if current.Score > input.alpha {
+			input.alpha = current.Score
+		}
+
+		if input.alpha >= input.beta {
+			recordCutoff(input, move)
+			break
+		}
+	}
+
+	result.Score = evaluation.IncMateDistance(result.Score, maxDepth)
+	storeTransposition(input, alphaOriginal, result)
+
+	return result, nil
+}
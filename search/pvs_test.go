@@ -0,0 +1,130 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/evaluation"
+	"github.com/leonhfr/honeybadger/oracle"
+	"github.com/leonhfr/honeybadger/quiescence"
+	"github.com/leonhfr/honeybadger/transposition"
+)
+
+var testPVSPositions = []string{
+	"5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1",
+	"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+	"2r3k1/1q1nbppp/r3p3/3pP3/pPpP4/P1Q2N2/2RN1PPP/2R4K b - b3 0 23",
+	"r1bqkbnr/ppp1p1pp/2n5/3pPp2/8/5N2/PPPP1PPP/RNBQKB1R w KQkq f6 0 4",
+}
+
+func TestPVS(t *testing.T) {
+	for _, fen := range testPVSPositions {
+		for depth := 1; depth <= 3; depth++ {
+			input := Input{
+				Position:      position(fen),
+				Depth:         depth,
+				alpha:         -evaluation.Mate,
+				beta:          evaluation.Mate,
+				Evaluation:    evaluation.Pesto{},
+				Oracle:        oracle.Order{},
+				Quiescence:    quiescence.None{},
+				Transposition: transposition.None{},
+			}
+
+			want, err := alphaBeta(context.Background(), input)
+			assert.Nil(t, err)
+
+			got, err := pvs(context.Background(), input)
+			assert.Nil(t, err)
+
+			assert.Equal(t, want.Score, got.Score, "%s at depth %d", fen, depth)
+			assert.Equal(t, want.PV, got.PV, "%s at depth %d", fen, depth)
+		}
+	}
+}
+
+func TestPVS_Search(t *testing.T) {
+	fen := "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+	output := make(chan *Output)
+	go func() {
+		defer close(output)
+		PVS{}.Search(context.Background(), Input{
+			Position:      position(fen),
+			Depth:         4,
+			Evaluation:    evaluation.Pesto{},
+			Oracle:        oracle.Order{},
+			Quiescence:    quiescence.None{},
+			Transposition: transposition.None{},
+		}, output)
+	}()
+
+	outputs := make(map[int][]*Output)
+	for o := range output {
+		outputs[o.Depth] = append(outputs[o.Depth], o)
+	}
+
+	var bounds int
+	for depth := 1; depth <= 4; depth++ {
+		want, err := alphaBeta(context.Background(), Input{
+			Position:      position(fen),
+			Depth:         depth,
+			alpha:         -evaluation.Mate,
+			beta:          evaluation.Mate,
+			Evaluation:    evaluation.Pesto{},
+			Oracle:        oracle.Order{},
+			Quiescence:    quiescence.None{},
+			Transposition: transposition.None{},
+		})
+		assert.Nil(t, err)
+
+		n := len(outputs[depth])
+		assert.NotZero(t, n, "depth %d", depth)
+		if n == 0 {
+			continue
+		}
+
+		last := outputs[depth][n-1]
+		assert.Equal(t, Exact, last.Bound, "depth %d", depth)
+		assert.Equal(t, want.Score, last.Score, "depth %d", depth)
+		assert.Equal(t, want.PV, last.PV, "depth %d", depth)
+
+		for _, o := range outputs[depth][:n-1] {
+			bounds++
+			switch o.Bound {
+			case LowerBound:
+				assert.LessOrEqual(t, o.Score, want.Score, "depth %d", depth)
+			case UpperBound:
+				assert.GreaterOrEqual(t, o.Score, want.Score, "depth %d", depth)
+				assert.Nil(t, o.PV)
+			default:
+				assert.Fail(t, "re-search with an exact score", "depth %d", depth)
+			}
+		}
+	}
+
+	assert.NotZero(t, bounds, "expected aspiration window re-searches")
+}
+
+func TestWidenAspirationWindow(t *testing.T) {
+	assert.Equal(t, 50, widenAlpha(100, 50))
+	assert.Equal(t, 150, widenBeta(100, 50))
+	assert.Equal(t, -evaluation.Mate, widenAlpha(100, 2*aspirationMaxWindow))
+	assert.Equal(t, evaluation.Mate, widenBeta(100, 2*aspirationMaxWindow))
+}
+
+func BenchmarkPVS(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_, _ = pvs(context.Background(), Input{
+			Position:      position("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1"),
+			Depth:         3,
+			alpha:         -evaluation.Mate,
+			beta:          evaluation.Mate,
+			Evaluation:    evaluation.Pesto{},
+			Oracle:        oracle.Order{},
+			Quiescence:    quiescence.None{},
+			Transposition: transposition.None{},
+		})
+	}
+}
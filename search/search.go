@@ -17,16 +17,28 @@ import (
 
 // Input holds a search input.
 type Input struct {
-	Position      *chess.Position         // Current board position.
-	SearchMoves   []*chess.Move           // Restrict search to those moves only.
-	Depth         int                     // Search <x> plies only.
-	alpha         int                     // Best score that the maximizer can guarantee.
-	beta          int                     // Best score that the minimizer can guarantee.
-	Search        Interface               // Search strategy to use.
-	Evaluation    evaluation.Interface    // Evaluation strategy to use.
-	Oracle        oracle.Interface        // Oracle strategy to use.
-	Quiescence    quiescence.Interface    // Quiescence strategy to use.
-	Transposition transposition.Interface // Transposition hash table strategy to use.
+	Position           *chess.Position         // Current board position.
+	SearchMoves        []*chess.Move           // Restrict search to those moves only.
+	Depth              int                     // Search <x> plies only.
+	alpha              int                     // Best score that the maximizer can guarantee.
+	beta               int                     // Best score that the minimizer can guarantee.
+	Search             Interface               // Search strategy to use.
+	Evaluation         evaluation.Interface    // Evaluation strategy to use.
+	Oracle             oracle.Interface        // Oracle strategy to use.
+	Quiescence         quiescence.Interface    // Quiescence strategy to use.
+	Transposition      transposition.Interface // Transposition hash table strategy to use.
+	NullMove           bool                    // Enables null move pruning.
+	NullMoveVerify     bool                    // Enables the verification of null move cutoffs at high depths.
+	LateMoveReductions bool                    // Enables late move reductions and pruning.
+	Extensions         Extension               // Search extensions to use.
+	ExtensionBudget    int                     // Maximum plies of extension along a line.
+	Margins            Margins                 // Forward pruning margins.
+	ply                int                     // Distance to the root in plies.
+	inCheck            bool                    // Whether the current player is in check.
+	previous           *chess.Move             // Move that led to the position.
+	extended           int                     // Plies of extension along the line.
+	excluded           *chess.Move             // Move excluded from the search.
+	skipNull           bool                    // Disables null move pruning at this node.
 }
 
 // Output holds a search output.
@@ -35,9 +47,20 @@ type Output struct {
 	Nodes int           // Number of nodes searched.
 	Score int           // Score from the engine's point of view in centipawns.
 	Mate  int           // Number of moves before mate. Positive for the current player to mate, negative for the current player to be mated.
+	Bound Bound         // Bound of the score.
 	PV    []*chess.Move // Principal variation, best line found.
+	Err   error         // Error that ended the search early.
 }
 
+// Bound represents the bound of a score.
+type Bound uint8
+
+const (
+	Exact      Bound = iota // Exact represents an exact score.
+	LowerBound              // LowerBound represents a score that is only a lower bound.
+	UpperBound              // UpperBound represents a score that is only an upper bound.
+)
+
 // Interface is the interface implemented by objects that can
 // run a search on a chess board.
 type Interface interface {
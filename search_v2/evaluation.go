@@ -14,6 +14,15 @@ func isTerminal(pos *chess.Position, moves int) (int, bool) {
 	}
 }
 
+// isDraw reports whether the game is drawn by insufficient material, the
+// fifty-move rule or repetition.
+//
+// A position repeated once is already scored as a draw: if repeating it
+// is the best option, it can be repeated until the draw can be claimed.
+func isDraw(game *chess.Game) bool {
+	return game.Repetitions() > 0 || game.Status().IsDraw()
+}
+
 func evaluate(pos *chess.Position) int {
 	var mg, eg, phase int
 
@@ -54,3 +63,17 @@ func incMateDistance(score int) int {
 	}
 	return score
 }
+
+// mateIn returns the number of moves before mate,
+// or 0 if the score is not a mate score.
+func mateIn(score int) int {
+	sign := 1
+	if score < 0 {
+		sign = -1
+	}
+	delta := mate - sign*score
+	if delta <= maxDepth {
+		return sign * (delta/2 + delta%2)
+	}
+	return 0
+}
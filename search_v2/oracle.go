@@ -8,6 +8,13 @@ import (
 
 var promoOraclePoints = [13]int{0, 0, 5, 5, -5, -5, -5, -5, 10, 10, 0, 0, 0}
 
+// mvvLvaOraclePoints ranks the pieces by value, indexed by chess.Piece:
+// both colors of a piece type share a rank and chess.NoPiece is last.
+var mvvLvaOraclePoints = [13]int{1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 0}
+
+// captureOraclePoints are given to all captures so they are sorted first.
+const captureOraclePoints = 10
+
 func orderMoves(moves []chess.Move) {
 	sort.Slice(moves, func(i, j int) bool {
 		return rank(moves[i]) > rank(moves[j])
@@ -19,7 +26,7 @@ func rank(move chess.Move) (n int) {
 
 	switch {
 	case move.HasTag(chess.Capture):
-		return 2
+		return n + captureOraclePoints + mvvLva(move)
 	case move.HasTag(chess.QueenSideCastle):
 		return 3
 	case move.HasTag(chess.KingSideCastle):
@@ -28,3 +35,12 @@ func rank(move chess.Move) (n int) {
 
 	return
 }
+
+// mvvLva ranks captures by most valuable victim, then least valuable attacker.
+func mvvLva(move chess.Move) int {
+	victim := move.P2()
+	if move.HasTag(chess.EnPassant) {
+		victim = chess.WhitePawn
+	}
+	return 8*mvvLvaOraclePoints[victim] - mvvLvaOraclePoints[move.P1()]
+}
@@ -21,11 +21,19 @@ func TestOrderMoves(t *testing.T) {
 			"tags",
 			"rnbq1knr/p1pp2pp/8/Pp6/8/8/8/R3K2R w KQ b6 0 1",
 			[]string{
-				"e1g1", "e1c1", "h1h7", "a5b6", "a1a2", "h1h5", "a1a3", "a1a4",
+				"a5b6", "h1h7", "e1g1", "e1c1", "a1a2", "h1h5", "a1a3", "a1a4",
 				"h1f1", "h1g1", "h1h2", "h1h3", "h1h4", "a5a6", "h1h6", "a1d1",
 				"e1d1", "e1f1", "e1d2", "e1e2", "e1f2", "a1c1", "a1b1",
 			},
 		},
+		{
+			"captures",
+			"4k3/8/2n5/3P4/4r3/8/3NQ3/4K3 w - - 0 1",
+			[]string{
+				"d2e4", "e2e4", "d5c6", "d2f1", "d2b3", "d2f3", "d2c4",
+				"d2b1", "e2e3", "d5d6", "e1d1", "e1f1", "e1f2",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -16,6 +16,22 @@ const (
 	draw = 0
 )
 
+// Input holds a search input.
+type Input struct {
+	Game        *chess.Game  // Current game, its history is used to detect draws.
+	SearchMoves []chess.Move // Restrict search to those moves only.
+	Depth       int          // Search <x> plies only.
+}
+
+// Output holds the output of an iteration of the search.
+type Output struct {
+	Depth int          // Search depth in plies.
+	Nodes int          // Number of nodes searched.
+	Score int          // Score from the current player's point of view in centipawns.
+	Mate  int          // Number of moves before mate. Positive for the current player to mate, negative for the current player to be mated.
+	PV    []chess.Move // Principal variation, best line found.
+}
+
 // output holds a search output.
 type output struct {
 	depth int
@@ -24,14 +40,90 @@ type output struct {
 	pv    []chess.Move // reversed
 }
 
-func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*output, error) {
+// Run starts an iterative deepening search: the position is searched
+// at depth 1, 2, ... up to the input depth, or the maximum depth when
+// none is provided. An output is sent after each completed iteration.
+//
+// The search runs on a copy of the game, which can be modified as soon
+// as Run returns.
+//
+// The channel is closed when the search is over or the context is done.
+func Run(ctx context.Context, input Input) <-chan *Output {
+	output := make(chan *Output)
+
+	if input.Depth <= 0 || input.Depth > maxDepth {
+		input.Depth = maxDepth
+	}
+
+	// the game is left in an unknown state when a search is canceled
+	game := input.Game.Copy()
+
+	go func() {
+		defer close(output)
+
+		moves := rootMoves(game.Position(), input.SearchMoves)
+
+		for depth := 1; depth <= input.Depth; depth++ {
+			o, err := searchRoot(ctx, game, moves, depth)
+			if err != nil {
+				return
+			}
+
+			select {
+			case output <- &Output{
+				Depth: depth,
+				Nodes: o.nodes,
+				Score: o.score,
+				Mate:  mateIn(o.score),
+				PV:    reverse(o.pv),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return output
+}
+
+// rootMoves returns the legal moves among the search moves,
+// or nil if there is none.
+func rootMoves(pos *chess.Position, searchMoves []chess.Move) []chess.Move {
+	var moves []chess.Move
+	for _, move := range pos.LegalMoves() {
+		for _, m := range searchMoves {
+			if move == m {
+				moves = append(moves, move)
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// searchRoot searches the root position, restricted to the moves if any.
+//
+// The moves of the root are searched even if it is a draw by repetition or
+// by the fifty-move rule, so that a best move is found.
+func searchRoot(ctx context.Context, game *chess.Game, moves []chess.Move, depth int) (*output, error) {
+	if len(moves) == 0 {
+		moves = game.Position().LegalMoves()
+	}
+	if len(moves) == 0 {
+		return search(ctx, game, -mate, mate, depth)
+	}
+	return searchMoves(ctx, game, moves, -mate, mate, depth)
+}
+
+func search(ctx context.Context, game *chess.Game, alpha, beta, depth int) (*output, error) {
 	select {
 	case <-ctx.Done():
 		return nil, context.Canceled
 	default:
 	}
 
-	moves := pos.PseudoMoves()
+	pos := game.Position()
+	moves := pos.LegalMoves()
 	score, terminal := isTerminal(pos, len(moves))
 	if terminal {
 		return &output{
@@ -40,6 +132,13 @@ func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*
 		}, nil
 	}
 
+	if isDraw(game) {
+		return &output{
+			nodes: 1,
+			score: draw,
+		}, nil
+	}
+
 	if depth == 0 {
 		return &output{
 			nodes: 1,
@@ -47,6 +146,11 @@ func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*
 		}, nil
 	}
 
+	return searchMoves(ctx, game, moves, alpha, beta, depth)
+}
+
+// searchMoves searches the legal moves of the position of the game.
+func searchMoves(ctx context.Context, game *chess.Game, moves []chess.Move, alpha, beta, depth int) (*output, error) {
 	result := &output{
 		depth: depth,
 		nodes: 0,
@@ -55,12 +159,8 @@ func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*
 
 	orderMoves(moves)
 	for _, move := range moves {
-		metadata, ok := pos.MakeMove(move)
-		if !ok {
-			continue
-		}
-
-		current, err := search(ctx, pos, -beta, -alpha, depth-1)
+		game.MakeMove(move)
+		current, err := search(ctx, game, -beta, -alpha, depth-1)
 		if err != nil {
 			return nil, err
 		}
@@ -76,7 +176,7 @@ func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*
 			alpha = current.score
 		}
 
-		pos.UnmakeMove(move, metadata)
+		game.UnmakeMove()
 
 		if alpha >= beta {
 			break
@@ -86,3 +186,12 @@ func search(ctx context.Context, pos *chess.Position, alpha, beta, depth int) (*
 	result.score = incMateDistance(result.score)
 	return result, nil
 }
+
+// reverse returns the moves in reverse order.
+func reverse(moves []chess.Move) []chess.Move {
+	reversed := make([]chess.Move, len(moves))
+	for i, move := range moves {
+		reversed[len(moves)-1-i] = move
+	}
+	return reversed
+}
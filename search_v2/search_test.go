@@ -5,13 +5,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/leonhfr/honeybadger/chess"
 )
 
 func TestSearch(t *testing.T) {
 	for _, tt := range testCheckmatePositions {
 		t.Run(tt.name, func(t *testing.T) {
 			pos := unsafeFEN(tt.fen)
-			output, err := search(context.Background(), pos, -mate, mate, tt.depth)
+			output, err := search(context.Background(), chess.NewGame(pos), -mate, mate, tt.depth)
 
 			assert.Equal(t, tt.output.score, output.score)
 			assert.Equal(t, tt.moves, movesString(output.pv))
@@ -23,10 +25,107 @@ func TestSearch(t *testing.T) {
 func BenchmarkSearch(b *testing.B) {
 	for _, bb := range testCheckmatePositions {
 		b.Run(bb.name, func(b *testing.B) {
-			pos := unsafeFEN(bb.fen)
+			game := chess.NewGame(unsafeFEN(bb.fen))
 			for n := 0; n < b.N; n++ {
-				_, _ = search(context.Background(), pos, -mate, mate, bb.depth)
+				_, _ = search(context.Background(), game, -mate, mate, bb.depth)
 			}
 		})
 	}
 }
+
+func TestRun(t *testing.T) {
+	game := chess.NewGame(unsafeFEN("5rk1/pb2npp1/1pq4p/5p2/5B2/1B6/P2RQ1PP/2r1R2K b - - 0 1"))
+	fen := game.Position().FEN()
+
+	var outputs []*Output
+	for o := range Run(context.Background(), Input{Game: game, Depth: 3}) {
+		outputs = append(outputs, o)
+	}
+
+	assert.Len(t, outputs, 3)
+	for i, o := range outputs {
+		assert.Equal(t, i+1, o.Depth)
+		assert.Len(t, o.PV, i+1)
+	}
+
+	last := outputs[len(outputs)-1]
+	assert.Equal(t, mate-3, last.Score)
+	assert.Equal(t, 2, last.Mate)
+	assert.Equal(t, []string{"c6g2", "e2g2", "c1e1"}, movesString(last.PV))
+	assert.Equal(t, fen, game.Position().FEN(), "position should not be modified")
+}
+
+func TestRun_SearchMoves(t *testing.T) {
+	pos := unsafeFEN("8/8/8/5K1k/8/8/8/5R2 w - - 0 1")
+	m, err := chess.MoveFromUCI(pos, "f1f2")
+	assert.Nil(t, err)
+
+	var last *Output
+	for o := range Run(context.Background(), Input{Game: chess.NewGame(pos), SearchMoves: []chess.Move{m}, Depth: 1}) {
+		last = o
+	}
+
+	assert.Equal(t, []string{"f1f2"}, movesString(last.PV))
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var outputs []*Output
+	for o := range Run(ctx, Input{Game: chess.NewGame(chess.StartingPosition())}) {
+		outputs = append(outputs, o)
+	}
+
+	assert.Empty(t, outputs)
+}
+
+func TestSearch_Draw(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		moves []string
+	}{
+		{"repetition", "7k/8/8/8/8/8/8/K2Q4 w - - 0 1", []string{"a1b1", "h8g8", "b1a1", "g8h8"}},
+		{"fifty move rule", "7k/8/8/8/8/8/8/K2Q4 w - - 100 80", nil},
+		{"insufficient material", "7k/8/8/8/8/8/8/K5N1 w - - 0 1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := chess.NewGame(unsafeFEN(tt.fen))
+			for _, uci := range tt.moves {
+				m, err := chess.MoveFromUCI(game.Position(), uci)
+				assert.Nil(t, err)
+				assert.True(t, game.MakeMove(m))
+			}
+
+			output, err := search(context.Background(), game, -mate, mate, 2)
+			assert.Nil(t, err)
+			assert.Equal(t, draw, output.score)
+			assert.Empty(t, output.pv)
+		})
+	}
+}
+
+func TestRun_Repetition(t *testing.T) {
+	// the queen is up but both players shuffle their kings
+	game := chess.NewGame(unsafeFEN("7k/8/8/8/8/8/8/K2Q4 b - - 0 1"))
+	for _, uci := range []string{"h8g8", "a1b1", "g8h8", "b1a1", "h8g8"} {
+		m, err := chess.MoveFromUCI(game.Position(), uci)
+		assert.Nil(t, err)
+		assert.True(t, game.MakeMove(m))
+	}
+
+	m, err := chess.MoveFromUCI(game.Position(), "a1b1")
+	assert.Nil(t, err)
+
+	var last *Output
+	for o := range Run(context.Background(), Input{Game: game, SearchMoves: []chess.Move{m}, Depth: 2}) {
+		last = o
+	}
+
+	// a1b1 repeats the position after h8g8 a1b1
+	assert.Equal(t, draw, last.Score)
+	assert.Equal(t, "a1b1", last.PV[0].String())
+}
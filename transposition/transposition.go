@@ -13,6 +13,7 @@ type Entry struct {
 	Score int
 	Depth int
 	Flag  Flag
+	Move  *chess.Move // Best move, nil if unknown.
 }
 
 // Flag represents the score bounds for this entry.
@@ -126,6 +126,7 @@ type commandUCINewGame struct{}
 
 // run implements the command interface.
 func (commandUCINewGame) run(ctx context.Context, e Engine, respond responder) {
+	e.NewGame()
 }
 
 // commandPosition represents a "position" command.
@@ -240,12 +241,16 @@ func (c commandGo) run(ctx context.Context, e Engine, respond responder) {
 	}
 
 	go func() {
-		var output Output
-		for output = range oc {
+		// outputs of re-searches may not hold a principal variation
+		var best string
+		for output := range oc {
 			respond(output)
+			if len(output.PV) > 0 {
+				best = output.PV[0]
+			}
 		}
-		if len(output.PV) > 0 {
-			respond(responseBestMove{output.PV[0]})
+		if best != "" {
+			respond(responseBestMove{best})
 		}
 	}()
 }
@@ -112,6 +112,7 @@ func TestCommandSetOption(t *testing.T) {
 
 func TestCommandUCINewGame(t *testing.T) {
 	e := new(mockEngine)
+	e.On("NewGame")
 
 	stdout := &strings.Builder{}
 	respond := newResponder(stdout)
@@ -213,6 +214,7 @@ func TestCommandGo(t *testing.T) {
 
 	output1 := Output{Score: 1000, PV: []string{"b1a3", "d2d4"}}
 	output2 := Output{Score: 2000, PV: []string{"d2d4"}}
+	output3 := Output{Score: 1500, Bound: BoundUpper}
 
 	tests := []struct {
 		name string
@@ -229,6 +231,11 @@ func TestCommandGo(t *testing.T) {
 			args{commandGo{Input{Depth: 3}}, []Output{output1, output2}, nil},
 			[]response{output1, output2, responseBestMove{output2.PV[0]}},
 		},
+		{
+			"go without pv",
+			args{commandGo{Input{Depth: 3}}, []Output{output1, output2, output3}, nil},
+			[]response{output1, output2, output3, responseBestMove{output2.PV[0]}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -194,6 +194,14 @@ func (o Output) String() string {
 	} else if o.Score != 0 {
 		res = append(res, "score cp", fmt.Sprint(o.Score))
 	}
+	if o.Mate != 0 || o.Score != 0 {
+		switch o.Bound {
+		case BoundLower:
+			res = append(res, "lowerbound")
+		case BoundUpper:
+			res = append(res, "upperbound")
+		}
+	}
 	if len(o.PV) > 0 {
 		res = append(res, "pv")
 		res = append(res, o.PV...)
@@ -201,6 +209,9 @@ func (o Output) String() string {
 	if o.Time > 0 {
 		res = append(res, "time", fmt.Sprint(o.Time.Milliseconds()))
 	}
+	if len(o.Info) > 0 {
+		res = append(res, "string", o.Info)
+	}
 
 	return fmt.Sprintf("info %s", strings.Join(res, " "))
 }
@@ -63,6 +63,38 @@ func TestResponseString(t *testing.T) {
 			},
 			want: "info depth 8 nodes 1024 score mate -5 pv b1a3 b1c3 time 5000",
 		},
+		{
+			name: "info lowerbound",
+			args: Output{
+				Depth: 8,
+				Nodes: 1024,
+				Score: 3000,
+				Bound: BoundLower,
+				PV:    []string{"b1a3"},
+				Time:  time.Duration(5e9),
+			},
+			want: "info depth 8 nodes 1024 score cp 3000 lowerbound pv b1a3 time 5000",
+		},
+		{
+			name: "info upperbound",
+			args: Output{
+				Depth: 8,
+				Nodes: 1024,
+				Score: -3000,
+				Bound: BoundUpper,
+				Time:  time.Duration(5e9),
+			},
+			want: "info depth 8 nodes 1024 score cp -3000 upperbound time 5000",
+		},
+		{
+			name: "info string",
+			args: Output{
+				Depth: 8,
+				Nodes: 1024,
+				Info:  "INFO",
+			},
+			want: "info depth 8 nodes 1024 string INFO",
+		},
 		{name: "comment", args: responseComment{comment: "COMMENT"}, want: "info string COMMENT"},
 		{
 			name: "option boolean",
@@ -21,6 +21,7 @@ type Engine interface {
 	SetOption(name, value string) error                             // SetOption sets an option.
 	SetPosition(fen string) error                                   // SetPosition sets the position to the provided FEN.
 	ResetPosition()                                                 // ResetPosition resets the position to the starting one.
+	NewGame()                                                       // NewGame prepares the engine for a new game.
 	Move(moves ...string) error                                     // Move plays the moves on the current position.
 	Search(ctx context.Context, input Input) (<-chan Output, error) // Search runs a search on the given input.
 	StopSearch()                                                    // StopSearch aborts a search prematurely.
@@ -96,9 +97,20 @@ type Output struct {
 	Nodes int           // Number of nodes searched.
 	Score int           // Score from the engine's point of view in centipawns.
 	Mate  int           // Number of moves before mate.
+	Bound Bound         // Bound of the score.
 	PV    []string      // Principal variation, best line found.
+	Info  string        // Any string to display, sent after the other fields.
 }
 
+// Bound represents the bound of a score.
+type Bound int
+
+const (
+	BoundExact Bound = iota // BoundExact represents an exact score.
+	BoundLower              // BoundLower represents a score that is only a lower bound.
+	BoundUpper              // BoundUpper represents a score that is only an upper bound.
+)
+
 // OptionType represents an option's type.
 type OptionType int
 
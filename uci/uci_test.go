@@ -86,6 +86,10 @@ func (m *mockEngine) ResetPosition() {
 	m.Called()
 }
 
+func (m *mockEngine) NewGame() {
+	m.Called()
+}
+
 func (m *mockEngine) Search(ctx context.Context, input Input) (<-chan Output, error) {
 	args := m.Called(ctx, input)
 	return args.Get(0).(chan Output), args.Error(1)